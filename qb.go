@@ -5,19 +5,17 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 
 	_ "github.com/lib/pq" // for PostgreSQL driver
 )
 
+// NewQbConn opens connection pool, the error of opening is returned by Err and by queries run on it,
+// use Open to get it right away and to ping the database
 func NewQbConn(driverName, dataSourceName string) *QbConn {
 	db, err := sql.Open(driverName, dataSourceName)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	return &QbConn{db: db}
+	return &QbConn{db: db, err: err}
 }
 
 func NewQbConnWith(db *sql.DB) *QbConn {
@@ -52,17 +50,25 @@ func (q *QbDB) reset() {
 	q.Builder.columns = []string{"*"}
 	q.Builder.where = ""
 	q.Builder.whereBindings = make([]map[string]any, 0)
-	q.Builder.groupBy = ""
+	q.Builder.groupBy = nil
 	q.Builder.having = ""
+	q.Builder.havingBindings = make([]map[string]any, 0)
 	q.Builder.orderBy = make([]map[string]string, 0)
+	q.Builder.orderClauses = nil
+	q.Builder.selectClauses = nil
 	q.Builder.offset = 0
 	q.Builder.limit = 0
 	q.Builder.join = []string{}
+	q.Builder.joins = nil
 	q.Builder.from = ""
 	q.Builder.lockForUpdate = nil
 	q.Builder.whereExists = ""
 	q.Builder.orderByRaw = nil
 	q.Builder.startBindingsAt = 1
+	q.Builder.keyColumns = nil
+	q.Builder.onConflict = ""
+	q.Builder.usePrimary = false
+	q.Builder.err = nil
 	if len(q.Builder.union) == 0 {
 		q.Builder.union = []string{}
 	}
@@ -71,6 +77,7 @@ func (q *QbDB) reset() {
 // Select accepts columns to select from a table
 func (q *QbDB) Select(args ...string) *QbDB {
 	q.Builder.columns = []string{}
+	q.Builder.selectClauses = nil
 	q.Builder.columns = append(q.Builder.columns, args...)
 	return q
 }
@@ -95,16 +102,49 @@ func (q *QbDB) InRandomOrder() *QbDB {
 	return q
 }
 
-// GroupBy adds GROUP BY expression to SQL stmt
-func (q *QbDB) GroupBy(expr string) *QbDB {
-	q.Builder.groupBy = expr
+// GroupBy adds GROUP BY expressions to SQL stmt, subsequent calls append more grouping elements
+func (q *QbDB) GroupBy(columns ...string) *QbDB {
+	q.Builder.groupBy = append(q.Builder.groupBy, columns...)
+	return q
+}
+
+// GroupByRollup adds ROLLUP (columns...) grouping element producing subtotals from right to left
+func (q *QbDB) GroupByRollup(columns ...string) *QbDB {
+	q.Builder.groupBy = append(q.Builder.groupBy, "ROLLUP ("+strings.Join(columns, ", ")+")")
+	return q
+}
+
+// GroupByCube adds CUBE (columns...) grouping element producing subtotals for all combinations
+func (q *QbDB) GroupByCube(columns ...string) *QbDB {
+	q.Builder.groupBy = append(q.Builder.groupBy, "CUBE ("+strings.Join(columns, ", ")+")")
+	return q
+}
+
+// GroupingSets adds GROUPING SETS ((a, b), (a), ()) grouping element, an empty set stands for the grand total
+func (q *QbDB) GroupingSets(sets ...[]string) *QbDB {
+	groups := make([]string, len(sets))
+	for i, set := range sets {
+		groups[i] = "(" + strings.Join(set, ", ") + ")"
+	}
+	q.Builder.groupBy = append(q.Builder.groupBy, "GROUPING SETS ("+strings.Join(groups, ", ")+")")
 	return q
 }
 
 // Having similar to Where but used with GroupBy to apply over the grouped results
 func (q *QbDB) Having(operand, operator string, value any) *QbDB {
-	q.Builder.having = operand + " " + operator + " " + transform2String(value)
-	return q
+	return q.buildHaving("", operand, operator, value)
+}
+
+// AndHaving accepts left operand-operator-right operand to apply them to having clause
+// with AND logical operator
+func (q *QbDB) AndHaving(operand, operator string, value any) *QbDB {
+	return q.buildHaving(SqlOperatorAnd, operand, operator, value)
+}
+
+// OrHaving accepts left operand-operator-right operand to apply them to having clause
+// with OR logical operator
+func (q *QbDB) OrHaving(operand, operator string, value any) *QbDB {
+	return q.buildHaving(SqlOperatorOr, operand, operator, value)
 }
 
 // HavingRaw accepts custom string to apply it to having clause
@@ -134,6 +174,7 @@ func (q *QbDB) AddSelect(args ...string) *QbDB {
 // SelectRaw accepts custom string to select from a table
 func (q *QbDB) SelectRaw(raw string) *QbDB {
 	q.Builder.columns = []string{raw}
+	q.Builder.selectClauses = nil
 	return q
 }
 
@@ -165,7 +206,8 @@ func (q *QbDB) Limit(value int64) *QbDB {
 // Page for pagination
 func (q *QbDB) Page(value int64) *QbDB {
 	if value < 0 {
-		log.Fatalf("Invalid page: %v", value)
+		q.Builder.setErr(fmt.Errorf("sql: invalid page: %d", value))
+		return q
 	}
 	if value > 0 {
 		value = value - 1
@@ -177,7 +219,8 @@ func (q *QbDB) Page(value int64) *QbDB {
 // Size for pagination
 func (q *QbDB) Size(value int64) *QbDB {
 	if value < 0 {
-		log.Fatalf("Invalid size(limit): %v", value)
+		q.Builder.setErr(fmt.Errorf("sql: invalid size(limit): %d", value))
+		return q
 	}
 	q.Builder.size = value
 	q.Limit(value)
@@ -188,21 +231,19 @@ func (q *QbDB) Size(value int64) *QbDB {
 // Drop drops >=1 tables
 func (q *QbDB) Drop(tables string) (sql.Result, error) {
 	query := fmt.Sprintf("%s%s", "DROP TABLE ", tables)
-	setCacheExecuteStmt(query)
-	return q.Sql().Exec(query)
+	return q.exec(query)
 }
 
 // Truncate clears >=1 tables
 func (q *QbDB) Truncate(tables string) (sql.Result, error) {
 	query := fmt.Sprintf("%s%s", "TRUNCATE ", tables)
-	setCacheExecuteStmt(query)
-	return q.Sql().Exec(query)
+	return q.exec(query)
 }
 
 // DropIfExists drops >=1 tables if they are existent
 func (q *QbDB) DropIfExists(tables ...string) (result sql.Result, err error) {
 	for _, table := range tables {
-		result, err = q.Sql().Exec(fmt.Sprintf("%s%s%s", "DROP TABLE", IfExistsExp, table))
+		result, err = q.exec(fmt.Sprintf("%s%s%s", "DROP TABLE", IfExistsExp, table))
 	}
 	return result, err
 }
@@ -210,8 +251,7 @@ func (q *QbDB) DropIfExists(tables ...string) (result sql.Result, err error) {
 // Rename renames from - to new table name
 func (q *QbDB) Rename(from, to string) (sql.Result, error) {
 	query := fmt.Sprintf("%s%s%s%s", "ALTER TABLE ", from, " RENAME TO ", to)
-	setCacheExecuteStmt(query)
-	return q.Sql().Exec(query)
+	return q.exec(query)
 }
 
 // From prepares sql stmt to set data from another table, ex.:
@@ -239,17 +279,17 @@ func (q *QbDB) GetQuery() string {
 	return q.Builder.buildSelect()
 }
 
-// PrintQueryWithExit prints raw sql to stdout and exit
+// PrintQueryWithExit prints raw sql to stdout, it no longer exits the process
+//
+// Deprecated: use PrintQuery or GetQuery instead
 func (q *QbDB) PrintQueryWithExit() {
 	q.PrintQuery()
-	os.Exit(0)
 }
 
 // HasTable determines whether table exists in particular schema
 func (q *QbDB) HasTable(schema, table string) (tblExists bool, err error) {
 	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM pg_tables WHERE  schemaname = '%s' AND tablename = '%s')", schema, table)
-	setCacheExecuteStmt(query)
-	err = q.Sql().QueryRow(query).Scan(&tblExists)
+	err = q.queryRow(query).Scan(&tblExists)
 	return
 }
 
@@ -259,8 +299,7 @@ func (q *QbDB) HasColumns(schema, table string, columns ...string) (colsExists b
 	for _, v := range columns { // todo: find a way to check columns in 1 query
 		andColumns = " AND column_name = '" + v + "'"
 		query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema='%s' AND table_name='%s'"+andColumns+")", schema, table)
-		setCacheExecuteStmt(query)
-		err = q.Sql().QueryRow(query).Scan(&colsExists)
+		err = q.queryRow(query).Scan(&colsExists)
 		if !colsExists { // if at least once col doesn't exist - return false, nil
 			return
 		}
@@ -272,11 +311,14 @@ func (q *QbDB) HasColumns(schema, table string, columns ...string) (colsExists b
 func (q *QbDB) Exists() (ok bool, err error) {
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return false, errTableCallBeforeOp
+		return false, ErrNoTable
+	}
+	if builder.err != nil {
+		return false, builder.err
 	}
 	query := `SELECT EXISTS(SELECT 1 FROM "` + builder.table + `" ` + builder.buildClauses() + `)`
-	setCacheExecuteStmt(query)
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&ok)
+	args, columns := builder.boundArgs()
+	err = q.bound(columns).readRow(query, args...).Scan(&ok)
 	return
 }
 
@@ -289,14 +331,28 @@ func (q *QbDB) DoesNotExists() (bool, error) {
 	return !ok, nil
 }
 
-// Increase column on passed value
+// Increase column on passed value for rows matching where clause
 func (q *QbDB) Increase(column string, value uint64) (int64, error) {
-	return q.increaseAndDecrease(column, plusSign, value)
+	return q.increaseAndDecrease(column, plusSign, value, nil)
 }
 
-// Decrease column on passed value
+// Decrease column on passed value for rows matching where clause
 func (q *QbDB) Decrease(column string, value uint64) (int64, error) {
-	return q.increaseAndDecrease(column, minusSign, value)
+	return q.increaseAndDecrease(column, minusSign, value, nil)
+}
+
+// Increment adds amount to column for rows matching where/from clauses returning affected rows,
+// extra columns are updated in the same statement, ex.:
+//
+//	db.Table("posts").Where("id", "=", 5).Increment("views", 1, map[string]any{"viewed_at": qb.Expr("NOW()")})
+func (q *QbDB) Increment(column string, amount float64, extra ...map[string]any) (int64, error) {
+	return q.increaseAndDecrease(column, plusSign, amount, extra)
+}
+
+// Decrement subtracts amount from column for rows matching where/from clauses returning affected rows,
+// extra columns are updated in the same statement
+func (q *QbDB) Decrement(column string, amount float64, extra ...map[string]any) (int64, error) {
+	return q.increaseAndDecrease(column, minusSign, amount, extra)
 }
 
 // Chunk run queries by chinks by passing user-land function with an ability to stop execution when needed
@@ -337,33 +393,59 @@ func (q *QbDB) Chunk(amount int64, fn func(rows []map[string]interface{}) bool)
 
 // buildSelect constructs a query for select statement
 func (q *qbBuilder) buildSelect() string {
-	query := `SELECT ` + strings.Join(q.columns, `, `) + ` FROM ` + q.table
-	v := fmt.Sprintf("%s%s", query, q.buildClauses())
+	columns, next := q.composeColumns()
+	query := `SELECT ` + strings.Join(columns, `, `) + ` FROM ` + q.table
+	v := fmt.Sprintf("%s%s", query, q.buildClausesAt(next))
 	setCacheExecuteStmt(v)
 	return v
 }
 
+// composeColumns renders select columns with bound expressions, returns the next free binding index
+func (q *qbBuilder) composeColumns() ([]string, int) {
+	next := q.startBindingsAt
+	columns := make([]string, len(q.columns))
+	k := 0
+	for i, col := range q.columns {
+		if col == clauseColumn && k < len(q.selectClauses) {
+			col, next = renderPlaceholders(q.selectClauses[k].sql, next)
+			k++
+		}
+		columns[i] = col
+	}
+	return columns, next
+}
+
 // builds query string clauses
 func (q *qbBuilder) buildClauses() string {
+	return q.buildClausesAt(q.startBindingsAt)
+}
+
+// builds query string clauses with bindings started at startedAt
+func (q *qbBuilder) buildClausesAt(startedAt int) string {
 	clauses := ""
 	for _, j := range q.join {
 		clauses += j
 	}
 	// build where clause
-	if len(q.whereBindings) > 0 {
-		clauses += composeWhere(q.whereBindings, q.startBindingsAt)
-	} else {
-		clauses += q.where // std without bindings todo: change all to bindings
-	}
-	if IsStringNotEmpty(q.groupBy) {
+	where, next := q.composeWhereAt(startedAt)
+	clauses += where
+	if len(q.groupBy) > 0 {
 		// clauses += " GROUP BY " + r.groupBy
-		clauses += fmt.Sprintf("%s%s", " GROUP BY ", q.groupBy)
+		clauses += fmt.Sprintf("%s%s", " GROUP BY ", strings.Join(q.groupBy, ", "))
 	}
-	if IsStringNotEmpty(q.having) {
+	if len(q.havingBindings) > 0 {
+		having := q.having
+		if IsStringEmpty(having) {
+			having = "1=1"
+		}
+		var bound string
+		bound, next = composeConditions(q.havingBindings, next)
+		clauses += fmt.Sprintf("%s%s%s", " HAVING ", having, bound)
+	} else if IsStringNotEmpty(q.having) {
 		// clauses += " HAVING " + r.having
 		clauses += fmt.Sprintf("%s%s", " HAVING ", q.having)
 	}
-	clauses += composeOrderBy(q.orderBy, q.orderByRaw)
+	clauses += composeOrderBy(q.orderBy, q.orderByRaw, q.orderClauses, next)
 	if q.limit > 0 {
 		// clauses += " LIMIT " + strconv.FormatInt(r.limit, 10)
 		clauses += fmt.Sprintf("%s%s", " LIMIT ", strconv.FormatInt(q.limit, 10))
@@ -378,19 +460,99 @@ func (q *qbBuilder) buildClauses() string {
 	return clauses
 }
 
-// increments or decrements depending on sign
-func (q *QbDB) increaseAndDecrease(column, sign string, on uint64) (int64, error) {
-	builder := q.Builder
-	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+// composeWhereAt builds where clause with bindings started at startedAt, returns the next free binding index
+// raw conditions of WhereRaw/AndWhereRaw/OrWhereRaw follow the bound ones
+func (q *qbBuilder) composeWhereAt(startedAt int) (string, int) {
+	if len(q.whereBindings) == 0 {
+		return q.where, startedAt // std without bindings todo: change all to bindings
 	}
-	query := `UPDATE "` + q.Builder.table + `" SET ` + column + ` = ` + column + sign + strconv.FormatUint(on, 10)
-	setCacheExecuteStmt(query)
-	result, err := q.Sql().Exec(query)
-	if err != nil {
-		return 0, err
+	where, next := composeWhere(q.whereBindings, startedAt)
+	if IsStringEmpty(q.where) {
+		return where, next
+	}
+	if raw := strings.TrimPrefix(q.where, Where); raw != q.where {
+		return where + And + "(" + raw + ")", next
+	}
+	return where + q.where, next
+}
+
+// composeJoinedClauses builds tables list of UPDATE ... FROM/DELETE ... USING and where clause, where bindings started at startedAt:
+// joins following the From table are kept as is, otherwise INNER joins are translated into their tables
+// and ON predicates ANDed to the where clause, other joins can't be translated without From table
+func (q *qbBuilder) composeJoinedClauses(startedAt int) (string, string, error) {
+	if len(q.joins) == 0 {
+		return q.from, q.buildClausesAt(startedAt), nil
+	}
+	where, _ := q.composeWhereAt(startedAt)
+	if IsStringNotEmpty(q.from) {
+		return q.from + strings.TrimRight(strings.Join(q.join, ""), " "), where, nil
+	}
+	tables := make([]string, len(q.joins))
+	predicates := make([]string, len(q.joins))
+	for i, j := range q.joins {
+		if j.kind != JoinInner {
+			return "", "", fmt.Errorf("sql: %s JOIN can't be used in UPDATE/DELETE without From() table", j.kind)
+		}
+		tables[i] = j.table
+		predicates[i] = j.on
+	}
+	conditions := strings.TrimSpace(where)
+	if IsStringEmpty(conditions) {
+		return strings.Join(tables, ", "), Where + strings.Join(predicates, And), nil
+	}
+	conditions = strings.TrimSpace(strings.TrimPrefix(conditions, strings.TrimSpace(Where)))
+	return strings.Join(tables, ", "), Where + "(" + conditions + ")" + And + strings.Join(predicates, And), nil
+}
+
+// boundArgs collects values bound to where, having and order by clauses in the order they are rendered
+// with the columns they are compared to
+func (q *qbBuilder) boundArgs() ([]any, []string) {
+	values, columns := prepareBoundValues(q.whereBindings)
+	having, havingColumns := prepareBoundValues(q.havingBindings)
+	values, columns = append(values, having...), append(columns, havingColumns...)
+	k := 0
+	for _, m := range q.orderBy {
+		if _, ok := m[clauseColumn]; ok && k < len(q.orderClauses) {
+			args := prepareClauseArgs(q.orderClauses[k])
+			values, columns = append(values, args...), append(columns, make([]string, len(args))...)
+			k++
+		}
+	}
+	return values, columns
+}
+
+// selectBindings collects values bound to select columns followed by the clauses ones, to be used with buildSelect
+func (q *qbBuilder) selectBindings() []any {
+	values, _ := q.selectBoundArgs()
+	return values
+}
+
+// selectBoundArgs collects values of selectBindings with the columns they are compared to
+func (q *qbBuilder) selectBoundArgs() ([]any, []string) {
+	var values []any
+	k := 0
+	for _, col := range q.columns {
+		if col == clauseColumn && k < len(q.selectClauses) {
+			values = append(values, prepareClauseArgs(q.selectClauses[k])...)
+			k++
+		}
+	}
+	columns := make([]string, len(values))
+	args, argColumns := q.boundArgs()
+	return append(values, args...), append(columns, argColumns...)
+}
+
+// increments or decrements column depending on sign applying where/from clauses,
+// extra columns are set in the same UPDATE statement, runs in transaction if it is active
+func (q *QbDB) increaseAndDecrease(column, sign string, on any, extra []map[string]any) (int64, error) {
+	data := make(map[string]any)
+	for _, m := range extra {
+		for k, v := range m {
+			data[k] = v
+		}
 	}
-	return result.RowsAffected()
+	data[column] = Expr(column+" "+sign+" ?", on)
+	return q.Update(data)
 }
 
 func (q *QbDB) GetRawSQL() string {
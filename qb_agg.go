@@ -1,46 +1,410 @@
 package qb
 
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Agg collects aggregate expressions to be computed in one round trip by Aggregate
+type Agg struct {
+	items []*AggExpr
+}
+
+// AggExpr is a single aggregate expression of Agg, optionally restricted by FILTER (WHERE ...)
+type AggExpr struct {
+	alias   string
+	expr    string // sql with ? placeholders
+	args    []any
+	filters []string
+	fArgs   []any
+}
+
+// AggResult holds aggregates computed by Aggregate keyed by alias, NULL values are kept as nil
+type AggResult struct {
+	values map[string]any
+}
+
+// GroupedRow is an aggregated row of GetGrouped with its group tuple
+type GroupedRow struct {
+	Key      []any          // values of key columns, nil where the column is rolled up
+	Grouping []bool         // GROUPING() flags, true where the column is rolled up (subtotal/total row)
+	Values   map[string]any // all selected columns of the row
+}
+
+// GroupAll stands for a rolled up key column in GroupKey, it never equals a column value, "*" included
+var GroupAll = qbGroupAll{}
+
+type qbGroupAll struct{}
+
+// groupingAlias is the column alias prefix of GROUPING() flags selected by GetGrouped
+const groupingAlias = "__grouping_"
+
 // Count counts resulting rows based on clause
 func (q *QbDB) Count() (countRows int64, err error) {
 	builder := q.Builder
+	if IsStringEmpty(builder.table) {
+		return 0, ErrNoTable
+	}
+	if builder.err != nil {
+		return 0, builder.err
+	}
+	columns := builder.columns
+	defer func() { builder.columns = columns }()
 	builder.columns = []string{"COUNT(*)"}
 	query := builder.buildSelect()
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&countRows)
+	args, argColumns := builder.selectBoundArgs()
+	err = q.bound(argColumns).readRow(query, args...).Scan(&countRows)
 	return
 }
 
-// Avg calculates average for specified column
+// Avg calculates average for specified column, 0 is returned when there are no rows
 func (q *QbDB) Avg(column string) (avg float64, err error) {
-	builder := q.Builder
-	builder.columns = []string{"AVG(" + column + ")"}
-	query := builder.buildSelect()
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&avg)
-	return
+	return q.aggregateFloat("AVG(" + column + ")")
 }
 
-// Min calculates minimum for specified column
+// Min calculates minimum for specified column, 0 is returned when there are no rows
 func (q *QbDB) Min(column string) (min float64, err error) {
-	builder := q.Builder
-	builder.columns = []string{"MIN(" + column + ")"}
-	query := builder.buildSelect()
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&min)
-	return
+	return q.aggregateFloat("MIN(" + column + ")")
 }
 
-// Max calculates maximum for specified column
+// Max calculates maximum for specified column, 0 is returned when there are no rows
 func (q *QbDB) Max(column string) (max float64, err error) {
+	return q.aggregateFloat("MAX(" + column + ")")
+}
+
+// Sum calculates sum for specified column, 0 is returned when there are no rows
+func (q *QbDB) Sum(column string) (max float64, err error) {
+	return q.aggregateFloat("SUM(" + column + ")")
+}
+
+// Aggregate computes several aggregates in one query, ex.:
+//
+//	result, err := db.Table("orders").Where("created_at", ">=", from).Aggregate(func(a *qb.Agg) {
+//		a.Count("total")
+//		a.CountDistinct("customer_id", "customers")
+//		a.Sum("amount", "paid").Filter("status", "=", "paid")
+//		a.PercentileCont(0.5, "amount", "median")
+//	})
+//
+// builder columns/order/limit are kept untouched, so the same builder may be executed afterwards
+func (q *QbDB) Aggregate(fn func(a *Agg)) (*AggResult, error) {
 	builder := q.Builder
-	builder.columns = []string{"MAX(" + column + ")"}
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	agg := &Agg{}
+	fn(agg)
+	if len(agg.items) == 0 {
+		return nil, fmt.Errorf("sql: there were no aggregates declared")
+	}
+	saved := *builder
+	defer func() { *builder = saved }()
+	columns, args, next := agg.compose(builder.startBindingsAt)
+	builder.columns = columns
+	builder.startBindingsAt = next
+	builder.orderBy = nil
+	builder.orderByRaw = nil
+	builder.limit = 0
+	builder.offset = 0
 	query := builder.buildSelect()
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&max)
+	whereArgs, whereColumns := builder.boundArgs()
+	argColumns := append(make([]string, len(args)), whereColumns...)
+	args = append(args, whereArgs...)
+	values := make([]any, len(agg.items))
+	pointers := make([]any, len(agg.items))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := q.bound(argColumns).readRow(query, args...).Scan(pointers...); err != nil {
+		return nil, err
+	}
+	result := &AggResult{values: make(map[string]any, len(agg.items))}
+	for i, item := range agg.items {
+		if b, ok := values[i].([]byte); ok {
+			result.values[item.alias] = string(b)
+		} else {
+			result.values[item.alias] = values[i]
+		}
+	}
+	return result, nil
+}
+
+// GetGrouped executes grouped query and returns aggregated rows keyed by group tuple of keyCols, ex.:
+//
+//	rows, err := db.Table("sales").Select("region", "year", "sum(amount) AS total").
+//		GroupByRollup("region", "year").GetGrouped("region", "year")
+//	total := rows[qb.GroupKey("EU", qb.GroupAll)] // EU subtotal over all years
+//
+// the key is built by GroupKey, rolled up columns are represented by GroupAll
+func (q *QbDB) GetGrouped(keyCols ...string) (map[string]*GroupedRow, error) {
+	builder := q.Builder
+	if len(keyCols) == 0 {
+		return nil, fmt.Errorf("sql: there were no key columns passed to GetGrouped")
+	}
+	columns := builder.columns
+	defer func() { builder.columns = columns }()
+	builder.columns = append([]string{}, columns...)
+	for i, col := range keyCols {
+		builder.columns = append(builder.columns, "GROUPING("+col+`) AS "`+groupingAlias+strconv.Itoa(i)+`"`)
+	}
+	rows, err := q.Get()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*GroupedRow, len(rows))
+	for _, row := range rows {
+		grouped := &GroupedRow{
+			Key:      make([]any, len(keyCols)),
+			Grouping: make([]bool, len(keyCols)),
+			Values:   row,
+		}
+		parts := make([]any, len(keyCols))
+		for i, col := range keyCols {
+			alias := groupingAlias + strconv.Itoa(i)
+			flag, _ := row[alias].(int64)
+			delete(row, alias)
+			if flag != 0 {
+				grouped.Grouping[i] = true
+				parts[i] = GroupAll
+				continue
+			}
+			grouped.Key[i] = row[unqualifiedColumn(col)]
+			parts[i] = grouped.Key[i]
+		}
+		result[GroupKey(parts...)] = grouped
+	}
+	return result, nil
+}
+
+// GroupKey builds the key of GetGrouped result from group tuple values, pass GroupAll for rolled up columns
+// and nil for NULL values; values are joined by | with \, | and * escaped, so rolled up columns are
+// encoded as * and NULL as \N, neither of them collides with a value
+func GroupKey(parts ...any) string {
+	keys := make([]string, len(parts))
+	for i, part := range parts {
+		switch v := part.(type) {
+		case qbGroupAll:
+			keys[i] = "*"
+		case nil:
+			keys[i] = `\N`
+		case []byte:
+			keys[i] = groupKeyEscaper.Replace(string(v))
+		default:
+			keys[i] = groupKeyEscaper.Replace(fmt.Sprint(v))
+		}
+	}
+	return strings.Join(keys, "|")
+}
+
+var groupKeyEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`)
+
+// Count adds count(*) aggregate
+func (a *Agg) Count(alias string) *AggExpr {
+	return a.Raw("count(*)", alias)
+}
+
+// CountColumn adds count(column) aggregate, NULL values are not counted
+func (a *Agg) CountColumn(column, alias string) *AggExpr {
+	return a.Raw("count("+column+")", alias)
+}
+
+// CountDistinct adds count(DISTINCT column) aggregate
+func (a *Agg) CountDistinct(column, alias string) *AggExpr {
+	return a.Raw("count(DISTINCT "+column+")", alias)
+}
+
+// Sum adds sum(column) aggregate
+func (a *Agg) Sum(column, alias string) *AggExpr {
+	return a.Raw("sum("+column+")", alias)
+}
+
+// SumDistinct adds sum(DISTINCT column) aggregate
+func (a *Agg) SumDistinct(column, alias string) *AggExpr {
+	return a.Raw("sum(DISTINCT "+column+")", alias)
+}
+
+// Avg adds avg(column) aggregate
+func (a *Agg) Avg(column, alias string) *AggExpr {
+	return a.Raw("avg("+column+")", alias)
+}
+
+// Min adds min(column) aggregate
+func (a *Agg) Min(column, alias string) *AggExpr {
+	return a.Raw("min("+column+")", alias)
+}
+
+// Max adds max(column) aggregate
+func (a *Agg) Max(column, alias string) *AggExpr {
+	return a.Raw("max("+column+")", alias)
+}
+
+// Stddev adds sample standard deviation stddev(column) aggregate
+func (a *Agg) Stddev(column, alias string) *AggExpr {
+	return a.Raw("stddev("+column+")", alias)
+}
+
+// StddevPop adds population standard deviation stddev_pop(column) aggregate
+func (a *Agg) StddevPop(column, alias string) *AggExpr {
+	return a.Raw("stddev_pop("+column+")", alias)
+}
+
+// Variance adds sample variance variance(column) aggregate
+func (a *Agg) Variance(column, alias string) *AggExpr {
+	return a.Raw("variance("+column+")", alias)
+}
+
+// PercentileCont adds continuous percentile, ex.: fraction 0.5 gives median of column
+func (a *Agg) PercentileCont(fraction float64, column, alias string) *AggExpr {
+	return a.Raw("percentile_cont(?) WITHIN GROUP (ORDER BY "+column+")", alias, fraction)
+}
+
+// PercentileDisc adds discrete percentile, the result is one of column values
+func (a *Agg) PercentileDisc(fraction float64, column, alias string) *AggExpr {
+	return a.Raw("percentile_disc(?) WITHIN GROUP (ORDER BY "+column+")", alias, fraction)
+}
+
+// ArrayAgg adds array_agg(column) aggregate, read it with AggResult.Strings
+func (a *Agg) ArrayAgg(column, alias string) *AggExpr {
+	return a.Raw("array_agg("+column+")", alias)
+}
+
+// StringAgg adds string_agg(column, separator) aggregate
+func (a *Agg) StringAgg(column, separator, alias string) *AggExpr {
+	return a.Raw("string_agg("+column+"::text, ?)", alias, separator)
+}
+
+// Raw adds custom aggregate expression with ? placeholders bound to args
+func (a *Agg) Raw(expr, alias string, args ...any) *AggExpr {
+	item := &AggExpr{alias: alias, expr: expr, args: args}
+	a.items = append(a.items, item)
+	return item
+}
+
+// Filter restricts the aggregate to rows where operand-operator-value holds: FILTER (WHERE ...),
+// several filters are joined by AND
+func (e *AggExpr) Filter(operand, operator string, value any) *AggExpr {
+	return e.FilterRaw(operand+" "+operator+" ?", value)
+}
+
+// FilterRaw restricts the aggregate by custom condition with ? placeholders bound to args
+func (e *AggExpr) FilterRaw(condition string, args ...any) *AggExpr {
+	e.filters = append(e.filters, condition)
+	e.fArgs = append(e.fArgs, args...)
+	return e
+}
+
+// compose renders select expressions and their bindings starting at startedAt
+func (a *Agg) compose(startedAt int) (columns []string, args []any, next int) {
+	next = startedAt
+	for _, item := range a.items {
+		expr := item.expr
+		itemArgs := item.args
+		if len(item.filters) > 0 {
+			expr += " FILTER (WHERE " + strings.Join(item.filters, And) + ")"
+			itemArgs = append(append([]any{}, item.args...), item.fArgs...)
+		}
+		expr, next = renderPlaceholders(expr, next)
+		columns = append(columns, expr+` AS "`+item.alias+`"`)
+		for _, arg := range itemArgs {
+			args = append(args, prepareArg(arg))
+		}
+	}
 	return
 }
 
-// Sum calculates sum for specified column
-func (q *QbDB) Sum(column string) (max float64, err error) {
+// Value gets the raw aggregate value, nil for NULL
+func (r *AggResult) Value(alias string) any {
+	return r.values[alias]
+}
+
+// Valid determines whether the aggregate is not NULL
+func (r *AggResult) Valid(alias string) bool {
+	return r.values[alias] != nil
+}
+
+// Int64 gets the aggregate as int64, 0 for NULL or non-numeric values
+func (r *AggResult) Int64(alias string) int64 {
+	switch v := r.values[alias].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+		f, _ := strconv.ParseFloat(v, 64)
+		return int64(f)
+	}
+	return 0
+}
+
+// Float64 gets the aggregate as float64, 0 for NULL or non-numeric values
+func (r *AggResult) Float64(alias string) float64 {
+	switch v := r.values[alias].(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+// String gets the aggregate as string, empty for NULL
+func (r *AggResult) String(alias string) string {
+	v := r.values[alias]
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// Strings gets array aggregate (array_agg) as a slice of strings, nil for NULL
+func (r *AggResult) Strings(alias string) []string {
+	s, ok := r.values[alias].(string)
+	if !ok {
+		return nil
+	}
+	var arr pq.StringArray
+	if err := arr.Scan(s); err != nil {
+		return nil
+	}
+	return arr
+}
+
+// Map gets all aggregates keyed by alias
+func (r *AggResult) Map() map[string]any {
+	return r.values
+}
+
+// calculates single aggregate keeping builder columns untouched, NULL results in 0
+func (q *QbDB) aggregateFloat(expr string) (float64, error) {
 	builder := q.Builder
-	builder.columns = []string{"SUM(" + column + ")"}
+	if IsStringEmpty(builder.table) {
+		return 0, ErrNoTable
+	}
+	if builder.err != nil {
+		return 0, builder.err
+	}
+	columns := builder.columns
+	defer func() { builder.columns = columns }()
+	builder.columns = []string{expr}
 	query := builder.buildSelect()
-	err = q.Sql().QueryRow(query, prepareValues(q.Builder.whereBindings)...).Scan(&max)
-	return
+	var value sql.NullFloat64
+	args, argColumns := builder.selectBoundArgs()
+	err := q.bound(argColumns).readRow(query, args...).Scan(&value)
+	return value.Float64, err
 }
@@ -0,0 +1,145 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestAggCompose(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(a *Agg)
+		start   int
+		columns []string
+		args    []any
+		next    int
+	}{
+		{
+			name: "plain aggregates have no bindings",
+			build: func(a *Agg) {
+				a.Count("total")
+				a.CountDistinct("customer_id", "customers")
+				a.Avg("amount", "avg")
+			},
+			start:   1,
+			columns: []string{`count(*) AS "total"`, `count(DISTINCT customer_id) AS "customers"`, `avg(amount) AS "avg"`},
+			next:    1,
+		},
+		{
+			name: "filters and percentile continue numbering",
+			build: func(a *Agg) {
+				a.Sum("amount", "paid").Filter("status", "=", "paid").Filter("amount", ">", 0)
+				a.PercentileCont(0.5, "amount", "median")
+				a.StringAgg("name", ", ", "names")
+			},
+			start: 3,
+			columns: []string{
+				`sum(amount) FILTER (WHERE status = $3 AND amount > $4) AS "paid"`,
+				`percentile_cont($5) WITHIN GROUP (ORDER BY amount) AS "median"`,
+				`string_agg(name::text, $6) AS "names"`,
+			},
+			args: []any{"paid", 0, 0.5, ", "},
+			next: 7,
+		},
+		{
+			name: "raw expression with filter binds expression args first",
+			build: func(a *Agg) {
+				a.Raw("sum(amount * ?)", "scaled", 2).FilterRaw("tags && ?", []string{"a"})
+			},
+			start:   1,
+			columns: []string{`sum(amount * $1) FILTER (WHERE tags && $2) AS "scaled"`},
+			args:    []any{2, prepareArg([]string{"a"})},
+			next:    3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			agg := &Agg{}
+			tc.build(agg)
+			columns, args, next := agg.compose(tc.start)
+			if !reflect.DeepEqual(columns, tc.columns) {
+				t.Errorf("columns:\n got: %q\nwant: %q", columns, tc.columns)
+			}
+			assertArgs(t, args, tc.args)
+			if next != tc.next {
+				t.Errorf("next = %d, want %d", next, tc.next)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"total", "avg", "names"}, [][]driver.Value{{int64(3), nil, []byte("{a,b}")}}
+	}
+	q := NewQbDb(fake.conn()).Table("orders").Select("id").Where("region", "=", "EU").OrderBy("id", "DESC").Limit(5)
+	result, err := q.Aggregate(func(a *Agg) {
+		a.Count("total")
+		a.Avg("amount", "avg").Filter("status", "=", "paid")
+		a.ArrayAgg("name", "names")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT count(*) AS "total", avg(amount) FILTER (WHERE status = $1) AS "avg", array_agg(name) AS "names" FROM orders WHERE 1=1  AND region = $2`
+	if got := fake.queries[0].sql; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+	if got := fake.queries[0].args; !reflect.DeepEqual(got, []driver.Value{"paid", "EU"}) {
+		t.Errorf("args = %#v", got)
+	}
+	if result.Int64("total") != 3 || result.Valid("avg") || result.Float64("avg") != 0 {
+		t.Errorf("result = %v", result.Map())
+	}
+	if got := result.Strings("names"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("names = %v", got)
+	}
+	if got := q.GetQuery(); got != "SELECT id FROM orders WHERE 1=1  AND region = $1 ORDER BY id DESC LIMIT 5" {
+		t.Errorf("builder was not restored: %s", got)
+	}
+}
+
+func TestAggregateFloatNull(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"avg"}, [][]driver.Value{{nil}}
+	}
+	q := NewQbDb(fake.conn()).Table("orders").Select("id")
+	avg, err := q.Avg("amount")
+	if err != nil || avg != 0 {
+		t.Fatalf("avg = %v, %v", avg, err)
+	}
+	if got := fake.statements()[0]; got != "SELECT AVG(amount) FROM orders" {
+		t.Errorf("sql = %s", got)
+	}
+	if got := q.GetQuery(); got != "SELECT id FROM orders" {
+		t.Errorf("builder columns were overwritten: %s", got)
+	}
+}
+
+func TestAggregatesWithoutTable(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(q *QbDB) error
+	}{
+		{name: "Count", run: func(q *QbDB) error { _, err := q.Count(); return err }},
+		{name: "Sum", run: func(q *QbDB) error { _, err := q.Sum("a"); return err }},
+		{name: "Avg", run: func(q *QbDB) error { _, err := q.Avg("a"); return err }},
+		{name: "Min", run: func(q *QbDB) error { _, err := q.Min("a"); return err }},
+		{name: "Max", run: func(q *QbDB) error { _, err := q.Max("a"); return err }},
+		{name: "Aggregate", run: func(q *QbDB) error { _, err := q.Aggregate(func(a *Agg) { a.Count("n") }); return err }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			if err := tc.run(NewQbDb(fake.conn())); err != ErrNoTable {
+				t.Errorf("err = %v, want ErrNoTable", err)
+			}
+			if got := fake.statements(); len(got) != 0 {
+				t.Errorf("ran %v", got)
+			}
+		})
+	}
+}
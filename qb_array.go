@@ -0,0 +1,91 @@
+package qb
+
+import "github.com/lib/pq"
+
+// WhereArrayContains appends column @> ARRAY stmt to WHERE clause,
+// matches rows where column holds every element of values
+func (q *QbDB) WhereArrayContains(column string, values any) *QbDB {
+	return q.buildWhere("", column, SqlOperatorArrayContains, pq.Array(values))
+}
+
+// AndWhereArrayContains appends column @> ARRAY stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereArrayContains(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorArrayContains, pq.Array(values))
+}
+
+// OrWhereArrayContains appends column @> ARRAY stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereArrayContains(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorArrayContains, pq.Array(values))
+}
+
+// WhereArrayContainedBy appends column <@ ARRAY stmt to WHERE clause,
+// matches rows where every element of column is found in values
+func (q *QbDB) WhereArrayContainedBy(column string, values any) *QbDB {
+	return q.buildWhere("", column, SqlOperatorArrayContainedBy, pq.Array(values))
+}
+
+// AndWhereArrayContainedBy appends column <@ ARRAY stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereArrayContainedBy(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorArrayContainedBy, pq.Array(values))
+}
+
+// OrWhereArrayContainedBy appends column <@ ARRAY stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereArrayContainedBy(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorArrayContainedBy, pq.Array(values))
+}
+
+// WhereArrayOverlaps appends column && ARRAY stmt to WHERE clause,
+// matches rows where column has at least one element in common with values
+func (q *QbDB) WhereArrayOverlaps(column string, values any) *QbDB {
+	return q.buildWhere("", column, SqlOperatorArrayOverlaps, pq.Array(values))
+}
+
+// AndWhereArrayOverlaps appends column && ARRAY stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereArrayOverlaps(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorArrayOverlaps, pq.Array(values))
+}
+
+// OrWhereArrayOverlaps appends column && ARRAY stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereArrayOverlaps(column string, values any) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorArrayOverlaps, pq.Array(values))
+}
+
+// WhereAny appends ANY stmt to WHERE clause, when value is a Go slice it is bound as an array:
+// column operator ANY($n), otherwise column is treated as an array column: $n operator ANY(column)
+func (q *QbDB) WhereAny(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier("", "ANY", column, operator, value)
+}
+
+// AndWhereAny appends ANY stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereAny(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier(SqlOperatorAnd, "ANY", column, operator, value)
+}
+
+// OrWhereAny appends ANY stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereAny(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier(SqlOperatorOr, "ANY", column, operator, value)
+}
+
+// WhereAll appends ALL stmt to WHERE clause, when value is a Go slice it is bound as an array:
+// column operator ALL($n), otherwise column is treated as an array column: $n operator ALL(column)
+func (q *QbDB) WhereAll(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier("", "ALL", column, operator, value)
+}
+
+// AndWhereAll appends ALL stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereAll(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier(SqlOperatorAnd, "ALL", column, operator, value)
+}
+
+// OrWhereAll appends ALL stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereAll(column, operator string, value any) *QbDB {
+	return q.buildWhereQuantifier(SqlOperatorOr, "ALL", column, operator, value)
+}
+
+// builds ANY/ALL array comparison depending on which side holds the array
+func (q *QbDB) buildWhereQuantifier(prefix, quantifier, column, operator string, value any) *QbDB {
+	if isArrayValue(value) {
+		return q.buildWhereClause(prefix, column+" "+operator+" "+quantifier+"(?)", value)
+	}
+	return q.buildWhereClause(prefix, "? "+operator+" "+quantifier+"("+column+")", value)
+}
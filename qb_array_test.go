@@ -0,0 +1,37 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestArrayPredicates(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name: "contains, overlaps, contained by",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereArrayContains("tags", []string{"a", "b"}).
+					OrWhereArrayOverlaps("ids", []int{1}).
+					AndWhereArrayContainedBy("roles", []string{"admin"})
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND tags @> $1 OR ids && $2 AND roles <@ $3",
+			args: []any{pq.Array([]string{"a", "b"}), pq.Array([]int{1}), pq.Array([]string{"admin"})},
+		},
+		{
+			name: "ANY with scalar, ALL with slice",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereAny("tags", "=", "a").AndWhereAll("scores", ">", []int{1, 2})
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND $1 = ANY(tags) AND scores > ALL($2)",
+			args: []any{"a", pq.Array([]int{1, 2})},
+		},
+	})
+}
+
+func TestArrayColumn(t *testing.T) {
+	table := (&QbTable{}).Array("tags", TypeText)
+	if got := composeColumn(table.columns[0]); got != "tags TEXT[]" {
+		t.Errorf("got %q, want %q", got, "tags TEXT[]")
+	}
+}
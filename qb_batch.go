@@ -0,0 +1,150 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpdateBatch updates multiple rows with different values by the key column in UPDATE ... FROM (VALUES ...) stmts,
+// returning the number of updated rows, ex.:
+//
+//	n, err := db.Table("products").UpdateBatch("id", []map[string]any{
+//		{"id": 1, "price": 10.5, "stock": 3},
+//		{"id": 2, "price": 7, "stock": 0},
+//	})
+//
+// every row must have the key and the same set of columns, values are cast to the column types of table,
+// rows are split into several stmts under MaxBindings, that run in transaction if it is active or in its own one
+func (q *QbDB) UpdateBatch(keyColumn string, rows []map[string]any) (int64, error) {
+	if len(rows) == 0 {
+		return 0, ErrEmptyData
+	}
+	builder := q.Builder
+	if IsStringEmpty(builder.table) {
+		return 0, ErrNoTable
+	}
+	columns, err := batchColumns(keyColumn, rows)
+	if err != nil {
+		return 0, err
+	}
+	var affected int64
+	err = q.withTxn(func() error {
+		types, err := q.columnTypes()
+		if err != nil {
+			return err
+		}
+		for _, column := range columns {
+			if _, ok := types[column]; !ok {
+				return fmt.Errorf("sql: column %q of table %q does not exist", column, builder.table)
+			}
+		}
+		for _, chunk := range chunkRows(columns, rows, MaxBindings) {
+			query, values, argColumns, err := builder.composeUpdateBatch(columns, types, chunk)
+			if err != nil {
+				return err
+			}
+			res, err := q.bound(argColumns).exec(query, values...)
+			if err != nil {
+				return err
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			affected += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// UpdateBatchIf updates multiple rows with different values by the key column returning the number of updated rows
+func (q *QbDB) UpdateBatchIf(keyColumn string, ops ...*QbOps) (int64, error) {
+	data := []map[string]any{}
+	for _, v := range ops {
+		data = append(data, v.GetArgs())
+	}
+	return q.UpdateBatch(keyColumn, data)
+}
+
+// composeUpdateBatch builds UPDATE ... FROM (VALUES ...) stmt, columns[0] is the key one,
+// returning its values with the columns they are set to
+func (q *qbBuilder) composeUpdateBatch(columns []string, types map[string]string, rows []map[string]any) (string, []any, []string, error) {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		c, err := quoteIdentifier(column)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		quoted[i] = c
+	}
+	var values []any
+	var argColumns []string
+	tuples := make([]string, len(rows))
+	i := 1
+	for k, row := range rows {
+		bindings := make([]string, len(columns))
+		for c, column := range columns {
+			var bound []any
+			bindings[c], bound, i = bindValue(row[column], i)
+			bindings[c] = "(" + bindings[c] + ")::" + types[column]
+			values = append(values, bound...)
+			argColumns = append(argColumns, repeatColumn(column, len(bound))...)
+		}
+		tuples[k] = "(" + strings.Join(bindings, ", ") + ")"
+	}
+	set := make([]string, 0, len(columns)-1)
+	for _, column := range quoted[1:] {
+		set = append(set, column+" = v."+column)
+	}
+	query := `UPDATE "` + q.table + `" SET ` + strings.Join(set, ", ") +
+		` FROM (VALUES ` + strings.Join(tuples, ", ") + `) AS v(` + strings.Join(quoted, ", ") + `)` +
+		` WHERE "` + q.table + `".` + quoted[0] + ` = v.` + quoted[0]
+	return query, values, argColumns, nil
+}
+
+// batchColumns gets columns of rows with the key one first, checking all rows have the same set of columns
+func batchColumns(keyColumn string, rows []map[string]any) ([]string, error) {
+	union := unionColumns(rows)
+	columns := []string{keyColumn}
+	for _, column := range union {
+		if column != keyColumn {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) < 2 {
+		return nil, fmt.Errorf("sql: there are no columns to update except key %q", keyColumn)
+	}
+	for k, row := range rows {
+		if _, ok := row[keyColumn]; !ok {
+			return nil, fmt.Errorf("sql: row %d misses key column %q", k, keyColumn)
+		}
+		if len(row) != len(union) || len(union) != len(columns) {
+			return nil, fmt.Errorf("sql: row %d columns differ from %v", k, columns)
+		}
+	}
+	return columns, nil
+}
+
+// columnTypes gets sql types of table columns from the catalog, e.g. numeric(10,2), timestamp with time zone
+func (q *QbDB) columnTypes() (map[string]string, error) {
+	query := `SELECT a.attname, format_type(a.atttypid, a.atttypmod) FROM pg_attribute a ` +
+		`WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped`
+	rows, err := q.query(query, `"`+q.Builder.table+`"`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	types := make(map[string]string)
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, err
+		}
+		types[name] = typ
+	}
+	return types, mapError(rows.Err())
+}
@@ -0,0 +1,102 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBatchColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "key goes first, the rest sorted",
+			rows: []map[string]any{{"stock": 1, "id": 1, "price": 2}, {"id": 2, "price": 3, "stock": 0}},
+			want: []string{"id", "price", "stock"},
+		},
+		{name: "key only", rows: []map[string]any{{"id": 1}}, wantErr: "no columns to update"},
+		{name: "missing key", rows: []map[string]any{{"id": 1, "a": 1}, {"a": 2}}, wantErr: "misses key column"},
+		{name: "different columns", rows: []map[string]any{{"id": 1, "a": 1}, {"id": 2, "b": 2}}, wantErr: "columns differ"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := batchColumns("id", tc.rows)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComposeUpdateBatch(t *testing.T) {
+	types := map[string]string{"id": "bigint", "price": "numeric(10,2)", "tags": "text[]"}
+	rows := []map[string]any{
+		{"id": 1, "price": 10.5, "tags": []string{"a"}},
+		{"id": 2, "price": Expr("price * ?", 2), "tags": nil},
+	}
+	query, args, _, err := newTestDB().Table("products").Builder.composeUpdateBatch([]string{"id", "price", "tags"}, types, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `UPDATE "products" SET "price" = v."price", "tags" = v."tags"` +
+		` FROM (VALUES (($1)::bigint, ($2)::numeric(10,2), ($3)::text[]), (($4)::bigint, (price * $5)::numeric(10,2), ($6)::text[]))` +
+		` AS v("id", "price", "tags") WHERE "products"."id" = v."id"`
+	if query != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", query, want)
+	}
+	assertArgs(t, args, []any{"1", "10.5", prepareArg([]string{"a"}), "2", 2, nil})
+
+	if _, _, _, err := newTestDB().Table("products").Builder.composeUpdateBatch([]string{"id", "a-b"}, types, rows); err == nil {
+		t.Error("expected invalid identifier error")
+	}
+}
+
+func TestUpdateBatch(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(query string) ([]string, [][]driver.Value) {
+		return []string{"attname", "format_type"}, [][]driver.Value{{"id", "integer"}, {"stock", "integer"}}
+	}
+	n, err := NewQbDb(fake.conn()).Table("products").UpdateBatch("id", []map[string]any{{"id": 1, "stock": 3}, {"id": 2, "stock": 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 { // the test driver reports one affected row per stmt
+		t.Errorf("affected = %d", n)
+	}
+	statements := fake.statements()
+	want := []string{
+		"BEGIN",
+		`SELECT a.attname, format_type(a.atttypid, a.atttypmod) FROM pg_attribute a WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped`,
+		`UPDATE "products" SET "stock" = v."stock" FROM (VALUES (($1)::integer, ($2)::integer), (($3)::integer, ($4)::integer)) AS v("id", "stock") WHERE "products"."id" = v."id"`,
+		"COMMIT",
+	}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("statements:\n got: %q\nwant: %q", statements, want)
+	}
+
+	fake = newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"attname", "format_type"}, [][]driver.Value{{"id", "integer"}}
+	}
+	_, err = NewQbDb(fake.conn()).Table("products").UpdateBatch("id", []map[string]any{{"id": 1, "stock": 3}})
+	if err == nil || !strings.Contains(err.Error(), `column "stock"`) {
+		t.Errorf("err = %v", err)
+	}
+	if got := fake.statements(); got[len(got)-1] != "ROLLBACK" {
+		t.Errorf("transaction was not rolled back: %q", got)
+	}
+}
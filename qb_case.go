@@ -0,0 +1,108 @@
+package qb
+
+import (
+	"strings"
+	"time"
+)
+
+// QbCase is the CASE WHEN expression builder with bound values, to be used in AddSelectCase,
+// OrderByCase and as a value of Insert/Update maps
+type QbCase struct {
+	whens   []qbCaseWhen
+	els     any
+	hasElse bool
+	alias   string
+}
+
+type qbCaseWhen struct {
+	condition string // sql with ? placeholders
+	args      []any
+	then      any
+}
+
+// Case starts CASE WHEN expression, ex.:
+//
+//	qb.Case().When("status = ?", "VIP", "vip").When("total > ?", "BIG", 1000).Else("REGULAR").As("segment")
+func Case() *QbCase {
+	return &QbCase{}
+}
+
+// When adds WHEN condition THEN value branch, ? placeholders of condition are bound to args in order
+func (c *QbCase) When(condition string, then any, args ...any) *QbCase {
+	c.whens = append(c.whens, qbCaseWhen{condition: condition, args: args, then: then})
+	return c
+}
+
+// Else sets ELSE value branch, NULL is the result of unmatched rows if it is not set
+func (c *QbCase) Else(value any) *QbCase {
+	c.els = value
+	c.hasElse = true
+	return c
+}
+
+// As sets column alias, applied when the expression is selected
+func (c *QbCase) As(alias string) *QbCase {
+	c.alias = alias
+	return c
+}
+
+// AddSelectCase adds CASE WHEN expression to the selected columns
+func (q *QbDB) AddSelectCase(c *QbCase) *QbDB {
+	clause := c.clause()
+	if IsStringNotEmpty(c.alias) {
+		clause = &qbClause{sql: clause.sql + ` AS "` + c.alias + `"`, args: clause.args}
+	}
+	q.Builder.columns = append(q.Builder.columns, clauseColumn)
+	q.Builder.selectClauses = append(q.Builder.selectClauses, clause)
+	return q
+}
+
+// OrderByCase adds ORDER BY CASE WHEN expression, ex.: custom order of statuses
+func (q *QbDB) OrderByCase(c *QbCase, direction string) *QbDB {
+	q.Builder.orderBy = append(q.Builder.orderBy, map[string]string{clauseColumn: direction})
+	q.Builder.orderClauses = append(q.Builder.orderClauses, c.clause())
+	return q
+}
+
+// clause renders CASE expression with ? placeholders
+func (c *QbCase) clause() *qbClause {
+	var sb strings.Builder
+	var args []any
+	sb.WriteString("CASE")
+	for _, when := range c.whens {
+		sb.WriteString(" WHEN " + when.condition + " THEN ")
+		args = append(args, when.args...)
+		args = appendCaseValue(&sb, args, when.then)
+	}
+	if c.hasElse {
+		sb.WriteString(" ELSE ")
+		args = appendCaseValue(&sb, args, c.els)
+	}
+	sb.WriteString(" END")
+	return &qbClause{sql: sb.String(), args: args}
+}
+
+// appendCaseValue renders THEN/ELSE value, placeholders are typed by Go value,
+// because CASE of untyped parameters is resolved as text
+func appendCaseValue(sb *strings.Builder, args []any, value any) []any {
+	switch v := value.(type) {
+	case nil:
+		sb.WriteString(SqlSpecificValueNull)
+		return args
+	case qbExpression:
+		clause := v.clause()
+		sb.WriteString(clause.sql)
+		return append(args, clause.args...)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		sb.WriteString("?::bigint")
+	case float32, float64:
+		sb.WriteString("?::numeric")
+	case bool:
+		sb.WriteString("?::boolean")
+	case time.Time:
+		sb.WriteString("?::timestamptz")
+	default:
+		sb.WriteString("?")
+	}
+	return append(args, value)
+}
@@ -0,0 +1,43 @@
+package qb
+
+import "testing"
+
+func TestCaseExpression(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name: "select case numbers before where and order",
+			build: func(q *QbDB) *QbDB {
+				return q.Select("id").
+					AddSelectCase(Case().When("status = ?", "vip", "V").When("total > ?", "big", 1000).Else("regular").As("segment")).
+					Where("region", "=", "EU").
+					OrderByCase(Case().When("status = ?", 0, "new").Else(1), "ASC")
+			},
+			sql: `SELECT id, CASE WHEN status = $1 THEN $2 WHEN total > $3 THEN $4 ELSE $5 END AS "segment" FROM t` +
+				` WHERE 1=1  AND region = $6 ORDER BY CASE WHEN status = $7 THEN $8::bigint ELSE $9::bigint END ASC`,
+			args: []any{"V", "vip", 1000, "big", "regular", "EU", "new", 0, 1},
+		},
+		{
+			name: "typed and NULL branches",
+			build: func(q *QbDB) *QbDB {
+				return q.Select("id").AddSelectCase(Case().When("a", 1.5).When("b", true).When("c", Expr("now() - ?::interval", "1 day")).When("d", nil))
+			},
+			sql:  "SELECT id, CASE WHEN a THEN $1::numeric WHEN b THEN $2::boolean WHEN c THEN now() - $3::interval WHEN d THEN NULL END FROM t",
+			args: []any{1.5, true, "1 day"},
+		},
+	})
+}
+
+func TestCaseInUpdate(t *testing.T) {
+	q := newTestDB().Table("users").Where("id", "=", 7)
+	query, args, _, err := q.Builder.composeUpdate(map[string]any{
+		"segment": Case().When("total > ?", "big", 1000).Else("small"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `UPDATE "users" SET segment = CASE WHEN total > $1 THEN $2 ELSE $3 END WHERE 1=1  AND id = $4`
+	if query != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", query, want)
+	}
+	assertArgs(t, args, []any{1000, "big", "small", "7"})
+}
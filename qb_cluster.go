@@ -0,0 +1,186 @@
+package qb
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaPolicy is the way reads are spread across replicas
+type ReplicaPolicy int
+
+const (
+	RoundRobin ReplicaPolicy = iota // replicas in turn
+	Random                          // random replica
+	LeastBusy                       // replica with the fewest connections in use
+)
+
+// qbCluster holds read replicas of QbConn
+type qbCluster struct {
+	replicas []*qbReplica
+	policy   ReplicaPolicy
+	next     atomic.Uint64
+	stop     chan struct{}
+	start    sync.Once // starts health checks
+	shutdown sync.Once // stops health checks
+}
+
+// defaultHealthCheckInterval is the interval of HealthCheck called with non-positive one
+const defaultHealthCheckInterval = 5 * time.Second
+
+type qbReplica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// NewQbCluster creates connection to primary with read replicas, ex.:
+//
+//	conn := qb.NewQbCluster(primary, replica1, replica2).SetReplicaPolicy(qb.LeastBusy).HealthCheck(5 * time.Second)
+//	db := qb.NewQbDb(conn)
+//	rows, err := db.Table("users").Get()              // replica
+//	n, err := db.Table("users").Update(data)          // primary
+//	row, err := db.Table("users").UsePrimary().First() // primary, to read own writes
+//
+// Get/First/Count/Exists/aggregates run on replicas, writes and transactions on primary,
+// reads run on primary when there is no healthy replica
+func NewQbCluster(primary *sql.DB, replicas ...*sql.DB) *QbConn {
+	cluster := &qbCluster{stop: make(chan struct{})}
+	for _, db := range replicas {
+		r := &qbReplica{db: db}
+		r.healthy.Store(true)
+		cluster.replicas = append(cluster.replicas, r)
+	}
+	return &QbConn{db: primary, cluster: cluster}
+}
+
+// OpenCluster opens primary and replicas with Open creating connection like NewQbCluster
+func OpenCluster(primary Config, replicas ...Config) (*QbConn, error) {
+	conn, err := Open(primary)
+	if err != nil {
+		return nil, err
+	}
+	dbs := make([]*sql.DB, 0, len(replicas))
+	for _, cfg := range replicas {
+		replica, err := Open(cfg)
+		if err != nil {
+			for _, db := range dbs {
+				_ = db.Close()
+			}
+			_ = conn.Close()
+			return nil, err
+		}
+		dbs = append(dbs, replica.db)
+	}
+	return NewQbCluster(conn.db, dbs...), nil
+}
+
+// SetReplicaPolicy sets the way reads are spread across replicas, RoundRobin by default
+func (c *QbConn) SetReplicaPolicy(policy ReplicaPolicy) *QbConn {
+	if c.cluster != nil {
+		c.cluster.policy = policy
+	}
+	return c
+}
+
+// HealthCheck pings replicas every interval ejecting failing ones from reads until they respond again,
+// non-positive interval defaults to 5s, only the first call starts checks and they are stopped by Close
+func (c *QbConn) HealthCheck(interval time.Duration) *QbConn {
+	if c.cluster == nil {
+		return c
+	}
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	cluster := c.cluster
+	cluster.start.Do(func() {
+		go cluster.healthCheck(interval, cluster.stop)
+	})
+	return c
+}
+
+// UsePrimary runs reads of the current call on primary, e.g. to read own writes not yet replicated
+func (q *QbDB) UsePrimary() *QbDB {
+	q.Builder.usePrimary = true
+	return q
+}
+
+// readExecutor gets executor reads run on: a replica unless transaction, UseExecutor, UsePrimary or LockForUpdate is in effect
+func (q *QbDB) readExecutor() Executor {
+	if (q.Txn != nil && q.Txn.Tx != nil) || q.executor != nil || q.Conn == nil || q.Conn.cluster == nil {
+		return q.Executor()
+	}
+	if q.Builder.usePrimary || q.Builder.lockForUpdate != nil {
+		return q.Executor()
+	}
+	if db := q.Conn.cluster.pick(); db != nil {
+		return db
+	}
+	return q.Executor()
+}
+
+// pick chooses a healthy replica by policy, nil if there is none
+func (c *qbCluster) pick() *sql.DB {
+	healthy := make([]*qbReplica, 0, len(c.replicas))
+	for _, r := range c.replicas {
+		if r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	switch c.policy {
+	case Random:
+		return healthy[rand.Intn(len(healthy))].db
+	case LeastBusy:
+		best := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.db.Stats().InUse < best.db.Stats().InUse {
+				best = r
+			}
+		}
+		return best.db
+	default:
+		return healthy[(c.next.Add(1)-1)%uint64(len(healthy))].db
+	}
+}
+
+// healthCheck pings replicas every interval until stop is closed
+func (c *qbCluster) healthCheck(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.ping(interval)
+		}
+	}
+}
+
+// ping marks replicas healthy if they respond within timeout
+func (c *qbCluster) ping(timeout time.Duration) {
+	for _, r := range c.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		r.healthy.Store(r.db.PingContext(ctx) == nil)
+		cancel()
+	}
+}
+
+// close stops health checks and closes replicas, it is safe to call more than once
+func (c *qbCluster) close() error {
+	c.shutdown.Do(func() {
+		close(c.stop)
+	})
+	var err error
+	for _, r := range c.replicas {
+		if errClose := r.db.Close(); errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+	return err
+}
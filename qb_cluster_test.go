@@ -0,0 +1,188 @@
+package qb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// fakeCluster is a cluster of fake primary and replicas
+type fakeCluster struct {
+	conn     *QbConn
+	primary  *fakeDB
+	replicas []*fakeDB
+	dbs      []*sql.DB
+}
+
+func newFakeCluster(replicas int) *fakeCluster {
+	c := &fakeCluster{primary: newFakeDB()}
+	for i := 0; i < replicas; i++ {
+		replica := newFakeDB()
+		c.replicas = append(c.replicas, replica)
+		c.dbs = append(c.dbs, sql.OpenDB(replica))
+	}
+	c.conn = NewQbCluster(sql.OpenDB(c.primary), c.dbs...)
+	return c
+}
+
+// ranOn gets name of the node each read ran on: "primary" or "replica<n>"
+func (c *fakeCluster) ranOn() []string {
+	var nodes []string
+	for range c.primary.statements() {
+		nodes = append(nodes, "primary")
+	}
+	for i, replica := range c.replicas {
+		for range replica.statements() {
+			nodes = append(nodes, "replica"+string(rune('1'+i)))
+		}
+	}
+	return nodes
+}
+
+func TestClusterRouting(t *testing.T) {
+	cases := []struct {
+		name  string
+		build func(q *QbDB) *QbDB
+		want  string
+	}{
+		{name: "read runs on replica", build: func(q *QbDB) *QbDB { return q }, want: "replica1"},
+		{name: "UsePrimary runs on primary", build: func(q *QbDB) *QbDB { return q.UsePrimary() }, want: "primary"},
+		{name: "LockForUpdate runs on primary", build: func(q *QbDB) *QbDB { return q.LockForUpdate() }, want: "primary"},
+		{name: "UseExecutor runs on executor", build: func(q *QbDB) *QbDB { return q.UseExecutor(q.Conn.db) }, want: "primary"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newFakeCluster(1)
+			defer cluster.conn.Close()
+			if _, err := tc.build(NewQbDb(cluster.conn).Table("t")).Get(); err != nil {
+				t.Fatal(err)
+			}
+			if got := cluster.ranOn(); len(got) != 1 || got[0] != tc.want {
+				t.Errorf("ran on %v, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClusterTransactionRunsOnPrimary(t *testing.T) {
+	cluster := newFakeCluster(1)
+	defer cluster.conn.Close()
+	err := NewQbDb(cluster.conn).Transaction(func(tx *QbTxn) error {
+		_, err := tx.Table("t").Get()
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cluster.replicas[0].statements(); len(got) != 0 {
+		t.Errorf("replica ran %v", got)
+	}
+	if got := cluster.primary.statements(); len(got) != 3 {
+		t.Errorf("primary ran %v, want BEGIN, SELECT, COMMIT", got)
+	}
+}
+
+func TestClusterPick(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  ReplicaPolicy
+		healthy []bool
+		want    []int // indexes of replicas picked by consecutive calls, -1 for none
+	}{
+		{name: "round robin", policy: RoundRobin, healthy: []bool{true, true, true}, want: []int{0, 1, 2, 0}},
+		{name: "round robin skips ejected", policy: RoundRobin, healthy: []bool{true, false, true}, want: []int{0, 2, 0}},
+		{name: "random gets the only healthy", policy: Random, healthy: []bool{false, true}, want: []int{1, 1}},
+		{name: "least busy", policy: LeastBusy, healthy: []bool{true, true}, want: []int{0, 0}},
+		{name: "least busy skips ejected", policy: LeastBusy, healthy: []bool{false, true}, want: []int{1}},
+		{name: "none healthy", policy: RoundRobin, healthy: []bool{false, false}, want: []int{-1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newFakeCluster(len(tc.healthy))
+			defer cluster.conn.Close()
+			cluster.conn.SetReplicaPolicy(tc.policy)
+			for i, healthy := range tc.healthy {
+				cluster.conn.cluster.replicas[i].healthy.Store(healthy)
+			}
+			for call, want := range tc.want {
+				got := cluster.conn.cluster.pick()
+				if want < 0 {
+					if got != nil {
+						t.Errorf("call %d: picked a replica, want none", call)
+					}
+					continue
+				}
+				if got != cluster.dbs[want] {
+					t.Errorf("call %d: picked another replica, want replica %d", call, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterEjection(t *testing.T) {
+	cluster := newFakeCluster(2)
+	defer cluster.conn.Close()
+	_ = cluster.dbs[0].Close() // a closed pool fails to ping
+	cluster.conn.cluster.ping(time.Second)
+	if cluster.conn.cluster.replicas[0].healthy.Load() {
+		t.Error("failing replica has not been ejected")
+	}
+	if !cluster.conn.cluster.replicas[1].healthy.Load() {
+		t.Error("responding replica has been ejected")
+	}
+	db := NewQbDb(cluster.conn)
+	for i := 0; i < 3; i++ {
+		if _, err := db.Table("t").Get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := cluster.replicas[1].statements(); len(got) != 3 {
+		t.Errorf("healthy replica ran %d reads, want 3", len(got))
+	}
+
+	cluster.conn.cluster.replicas[1].healthy.Store(false)
+	if _, err := db.Table("t").Get(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cluster.primary.statements(); len(got) != 1 {
+		t.Errorf("primary ran %v, want the read with no healthy replica", got)
+	}
+}
+
+func TestClusterHealthCheck(t *testing.T) {
+	cases := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{name: "positive interval", interval: time.Millisecond},
+		{name: "zero interval defaults", interval: 0},
+		{name: "negative interval defaults", interval: -time.Second},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newFakeCluster(1)
+			cluster.conn.HealthCheck(tc.interval).HealthCheck(tc.interval) // must not panic nor start twice
+			if err := cluster.conn.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := cluster.conn.cluster.close(); err != nil { // second close must not panic
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestClusterHealthCheckEjects(t *testing.T) {
+	cluster := newFakeCluster(1)
+	defer cluster.conn.Close()
+	_ = cluster.dbs[0].Close()
+	cluster.conn.HealthCheck(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for cluster.conn.cluster.replicas[0].healthy.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("failing replica has not been ejected by health check")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
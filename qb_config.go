@@ -1,6 +1,9 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 // list all join types
 const (
@@ -23,6 +26,40 @@ const (
 	SqlOperatorOr         = "OR"
 )
 
+// list all array operators
+const (
+	SqlOperatorArrayContains    = "@>"
+	SqlOperatorArrayContainedBy = "<@"
+	SqlOperatorArrayOverlaps    = "&&"
+)
+
+// list all pattern matching operators
+const (
+	SqlOperatorLike       = "LIKE"
+	SqlOperatorILike      = "ILIKE"
+	SqlOperatorNotLike    = "NOT LIKE"
+	SqlOperatorSimilarTo  = "SIMILAR TO"
+	SqlOperatorRegex      = "~"
+	SqlOperatorIRegex     = "~*"
+	likeEscapeChar        = `\`
+	likeWildcardAny       = "%"
+	likeWildcardCharacter = "_"
+)
+
+// list all geometric operators
+const (
+	SqlOperatorGeoDistance    = "<->"
+	SqlOperatorGeoContains    = "@>"
+	SqlOperatorGeoContainedBy = "<@"
+)
+
+// list all index methods
+const (
+	IndexMethodBtree = "BTREE"
+	IndexMethodGist  = "GIST"
+	IndexMethodGin   = "GIN"
+)
+
 // list all invalid types
 const (
 	SqlSpecificValueNull    = "NULL"
@@ -67,26 +104,57 @@ const (
 	TypeJsonb        = "JSONB"
 	TypePoint        = "POINT"
 	TypePolygon      = "POLYGON"
+	TypeArray        = "[]"
 )
 
 // specific for PostgreSQL driver and SQL std
 const (
-	DefaultSchema  = "public"
-	SemiColon      = ";"
-	AlterTable     = "ALTER TABLE "
-	Add            = " ADD "
-	Modify         = " ALTER "
-	Drop           = " DROP "
-	Rename         = " RENAME "
-	IfExistsExp    = " IF EXISTS "
-	IfNotExistsExp = " IF NOT EXISTS "
-	Concurrently   = " CONCURRENTLY "
-	Constraint     = " CONSTRAINT "
+	DefaultKeyColumn = "id"
+	MaxBindings      = 65535 // PostgreSQL limit of bind parameters per stmt
+	DefaultSchema    = "public"
+	SemiColon        = ";"
+	AlterTable       = "ALTER TABLE "
+	Add              = " ADD "
+	Modify           = " ALTER "
+	Drop             = " DROP "
+	Rename           = " RENAME "
+	IfExistsExp      = " IF EXISTS "
+	IfNotExistsExp   = " IF NOT EXISTS "
+	Concurrently     = " CONCURRENTLY "
+	Constraint       = " CONSTRAINT "
+)
+
+// SQLSTATE codes of errors the transaction can be retried on
+const (
+	SqlStateSerializationFailure = "40001"
+	SqlStateDeadlockDetected     = "40P01"
 )
 
+// list all operators allowed to compare columns
+var columnComparisonOperators = map[string]bool{
+	"=":                    true,
+	"<>":                   true,
+	"!=":                   true,
+	"<":                    true,
+	"<=":                   true,
+	">":                    true,
+	">=":                   true,
+	"IS DISTINCT FROM":     true,
+	"IS NOT DISTINCT FROM": true,
+}
+
 var (
-	errTableCallBeforeOp        = fmt.Errorf("sql: there was no Table() call with table name set")
 	errTransactionModeWithoutTx = fmt.Errorf("sql: there was no *sql.Tx object set properly")
+	errUpsertWithoutTarget      = fmt.Errorf("sql: there was no OnConflict() or OnConstraint() call for DO UPDATE")
+	errNilQbDB                  = fmt.Errorf("sql: there was no QbDB set for transaction, create it with Begin or Transaction")
+)
+
+// clauseColumn marks select/order by entries rendered from bound expressions
+const clauseColumn = "\x00"
+
+var (
+	// identifierRegexp matches a single unquoted part of sql identifier
+	identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
 )
 
 var (
@@ -0,0 +1,192 @@
+package qb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of connection pool opened by Open, ex.:
+//
+//	conn, err := qb.Open(qb.Config{
+//		Host:         "localhost",
+//		Port:         5432,
+//		User:         "postgres",
+//		Password:     "secret",
+//		Database:     "app",
+//		SSLMode:      "disable",
+//		MaxOpenConns: 20,
+//		PingAttempts: 5,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//	db := qb.NewQbDb(conn)
+type Config struct {
+	DSN            string // URL or key=value connection string, discrete fields are ignored if set
+	Host           string // localhost by default
+	Port           int    // 5432 by default
+	User           string
+	Password       string
+	Database       string
+	SSLMode        string        // disable, require, verify-ca, verify-full
+	ConnectTimeout time.Duration // connect_timeout, rounded up to seconds
+
+	MaxOpenConns    int           // 0 for unlimited
+	MaxIdleConns    int           // 0 for database/sql default (2)
+	ConnMaxLifetime time.Duration // 0 for unlimited
+	ConnMaxIdleTime time.Duration // 0 for unlimited
+
+	PingAttempts int           // attempts to ping the database on Open, 1 by default, -1 to skip ping
+	PingBackoff  time.Duration // delay before the 2nd ping doubled for each next one, 500ms by default
+}
+
+// ConfigFromEnv loads Config from environment variables prefix+DSN, HOST, PORT, USER, PASSWORD, DATABASE, SSLMODE,
+// CONNECT_TIMEOUT, MAX_OPEN_CONNS, MAX_IDLE_CONNS, CONN_MAX_LIFETIME, CONN_MAX_IDLE_TIME, PING_ATTEMPTS, PING_BACKOFF,
+// durations are parsed by time.ParseDuration, ex.: DB_HOST, DB_CONN_MAX_LIFETIME=30m for prefix "DB_"
+func ConfigFromEnv(prefix string) (Config, error) {
+	cfg := Config{
+		DSN:      os.Getenv(prefix + "DSN"),
+		Host:     os.Getenv(prefix + "HOST"),
+		User:     os.Getenv(prefix + "USER"),
+		Password: os.Getenv(prefix + "PASSWORD"),
+		Database: os.Getenv(prefix + "DATABASE"),
+		SSLMode:  os.Getenv(prefix + "SSLMODE"),
+	}
+	ints := map[string]*int{
+		"PORT":           &cfg.Port,
+		"MAX_OPEN_CONNS": &cfg.MaxOpenConns,
+		"MAX_IDLE_CONNS": &cfg.MaxIdleConns,
+		"PING_ATTEMPTS":  &cfg.PingAttempts,
+	}
+	for key, dest := range ints {
+		value := os.Getenv(prefix + key)
+		if IsStringEmpty(value) {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return cfg, fmt.Errorf("sql: invalid %s%s: %w", prefix, key, err)
+		}
+		*dest = n
+	}
+	durations := map[string]*time.Duration{
+		"CONNECT_TIMEOUT":    &cfg.ConnectTimeout,
+		"CONN_MAX_LIFETIME":  &cfg.ConnMaxLifetime,
+		"CONN_MAX_IDLE_TIME": &cfg.ConnMaxIdleTime,
+		"PING_BACKOFF":       &cfg.PingBackoff,
+	}
+	for key, dest := range durations {
+		value := os.Getenv(prefix + key)
+		if IsStringEmpty(value) {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return cfg, fmt.Errorf("sql: invalid %s%s: %w", prefix, key, err)
+		}
+		*dest = d
+	}
+	return cfg, nil
+}
+
+// DataSourceName gets DSN of config, built from discrete fields if DSN isn't set
+func (c Config) DataSourceName() string {
+	if IsStringNotEmpty(c.DSN) {
+		return c.DSN
+	}
+	host, port := c.Host, c.Port
+	if IsStringEmpty(host) {
+		host = "localhost"
+	}
+	if port == 0 {
+		port = 5432
+	}
+	params := []string{"host=" + quoteDsnValue(host), "port=" + strconv.Itoa(port)}
+	fields := [][2]string{{"user", c.User}, {"password", c.Password}, {"dbname", c.Database}, {"sslmode", c.SSLMode}}
+	for _, field := range fields {
+		if IsStringNotEmpty(field[1]) {
+			params = append(params, field[0]+"="+quoteDsnValue(field[1]))
+		}
+	}
+	if c.ConnectTimeout > 0 {
+		// rounded up, as connect_timeout=0 means waiting forever
+		seconds := (c.ConnectTimeout + time.Second - 1) / time.Second
+		params = append(params, "connect_timeout="+strconv.Itoa(int(seconds)))
+	}
+	return strings.Join(params, " ")
+}
+
+// Open opens PostgreSQL connection pool with the config and pings the database, retrying with backoff
+func Open(cfg Config) (*QbConn, error) {
+	db, err := sql.Open("postgres", cfg.DataSourceName())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	if cfg.MaxIdleConns != 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	if err = ping(db, cfg.PingAttempts, cfg.PingBackoff); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &QbConn{db: db}, nil
+}
+
+// Err gets the error of opening connection pool by NewQbConn
+func (c *QbConn) Err() error {
+	return c.err
+}
+
+// Close closes connection pool and replicas of cluster
+func (c *QbConn) Close() error {
+	if c.db == nil {
+		return c.err
+	}
+	err := c.db.Close()
+	if c.cluster != nil {
+		if errReplicas := c.cluster.close(); err == nil {
+			err = errReplicas
+		}
+	}
+	return err
+}
+
+// ping pings the database up to attempts times doubling backoff after each failure
+func ping(db *sql.DB, attempts int, backoff time.Duration) error {
+	if attempts < 0 {
+		return nil
+	}
+	if attempts == 0 {
+		attempts = 1
+	}
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = db.Ping(); err == nil {
+			return nil
+		}
+		if attempt < attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("sql: ping failed after %d attempts: %w", attempts, mapError(err))
+}
+
+// quoteDsnValue quotes value of key=value DSN if it has spaces, quotes or backslashes
+func quoteDsnValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,79 @@
+package qb
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataSourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{name: "defaults", cfg: Config{}, want: "host=localhost port=5432"},
+		{
+			name: "all fields",
+			cfg:  Config{Host: "db", Port: 6432, User: "app", Password: "s3cret", Database: "main", SSLMode: "require", ConnectTimeout: 5 * time.Second},
+			want: "host=db port=6432 user=app password=s3cret dbname=main sslmode=require connect_timeout=5",
+		},
+		{name: "quoted values", cfg: Config{Password: `a b'c\d`}, want: `host=localhost port=5432 password='a b\'c\\d'`},
+		{name: "dsn wins", cfg: Config{DSN: "postgres://u@h/db", Host: "ignored"}, want: "postgres://u@h/db"},
+		{name: "sub second timeout is not 0", cfg: Config{ConnectTimeout: 200 * time.Millisecond}, want: "host=localhost port=5432 connect_timeout=1"},
+		{name: "timeout rounded up", cfg: Config{ConnectTimeout: 1200 * time.Millisecond}, want: "host=localhost port=5432 connect_timeout=2"},
+		{name: "whole seconds kept", cfg: Config{ConnectTimeout: 2 * time.Second}, want: "host=localhost port=5432 connect_timeout=2"},
+		{name: "negative timeout omitted", cfg: Config{ConnectTimeout: -time.Second}, want: "host=localhost port=5432"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cfg.DataSourceName(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("TQB_HOST", "db")
+	t.Setenv("TQB_PORT", "6432")
+	t.Setenv("TQB_USER", "app")
+	t.Setenv("TQB_MAX_OPEN_CONNS", "20")
+	t.Setenv("TQB_CONNECT_TIMEOUT", "3s")
+	t.Setenv("TQB_CONN_MAX_LIFETIME", "30m")
+	cfg, err := ConfigFromEnv("TQB_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{Host: "db", Port: 6432, User: "app", MaxOpenConns: 20, ConnectTimeout: 3 * time.Second, ConnMaxLifetime: 30 * time.Minute}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	tests := map[string]string{"TQB_PORT": "x", "TQB_PING_BACKOFF": "5"}
+	for key, value := range tests {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := ConfigFromEnv("TQB_"); err == nil || !strings.Contains(err.Error(), key) {
+				t.Errorf("err = %v, want error naming %s", err, key)
+			}
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	db := sql.OpenDB(newFakeDB())
+	defer db.Close()
+	if err := ping(db, 0, 0); err != nil {
+		t.Errorf("ping = %v", err)
+	}
+	if err := ping(db, -1, 0); err != nil {
+		t.Errorf("skipped ping = %v", err)
+	}
+	closed := sql.OpenDB(newFakeDB())
+	closed.Close()
+	if err := ping(closed, 2, time.Millisecond); err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("ping on closed pool = %v", err)
+	}
+}
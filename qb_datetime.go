@@ -0,0 +1,183 @@
+package qb
+
+import "time"
+
+// WhereDate compares the date part of column with the date of value
+func (q *QbDB) WhereDate(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause("", column+"::date "+operator+" ?::date", value)
+}
+
+// AndWhereDate compares the date part of column with the date of value with AND logical operator
+func (q *QbDB) AndWhereDate(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+"::date "+operator+" ?::date", value)
+}
+
+// OrWhereDate compares the date part of column with the date of value with OR logical operator
+func (q *QbDB) OrWhereDate(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+"::date "+operator+" ?::date", value)
+}
+
+// WhereYear compares the year of column with year
+func (q *QbDB) WhereYear(column, operator string, year int) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(YEAR FROM "+column+") "+operator+" ?", year)
+}
+
+// AndWhereYear compares the year of column with year with AND logical operator
+func (q *QbDB) AndWhereYear(column, operator string, year int) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(YEAR FROM "+column+") "+operator+" ?", year)
+}
+
+// OrWhereYear compares the year of column with year with OR logical operator
+func (q *QbDB) OrWhereYear(column, operator string, year int) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(YEAR FROM "+column+") "+operator+" ?", year)
+}
+
+// WhereMonth compares the month (1-12) of column with month
+func (q *QbDB) WhereMonth(column, operator string, month int) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(MONTH FROM "+column+") "+operator+" ?", month)
+}
+
+// AndWhereMonth compares the month (1-12) of column with month with AND logical operator
+func (q *QbDB) AndWhereMonth(column, operator string, month int) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(MONTH FROM "+column+") "+operator+" ?", month)
+}
+
+// OrWhereMonth compares the month (1-12) of column with month with OR logical operator
+func (q *QbDB) OrWhereMonth(column, operator string, month int) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(MONTH FROM "+column+") "+operator+" ?", month)
+}
+
+// WhereDay compares the day of month of column with day
+func (q *QbDB) WhereDay(column, operator string, day int) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(DAY FROM "+column+") "+operator+" ?", day)
+}
+
+// AndWhereDay compares the day of month of column with day with AND logical operator
+func (q *QbDB) AndWhereDay(column, operator string, day int) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(DAY FROM "+column+") "+operator+" ?", day)
+}
+
+// OrWhereDay compares the day of month of column with day with OR logical operator
+func (q *QbDB) OrWhereDay(column, operator string, day int) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(DAY FROM "+column+") "+operator+" ?", day)
+}
+
+// WhereTime compares the time of day of column with the time of value
+func (q *QbDB) WhereTime(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause("", column+"::time "+operator+" ?::time", value)
+}
+
+// AndWhereTime compares the time of day of column with the time of value with AND logical operator
+func (q *QbDB) AndWhereTime(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+"::time "+operator+" ?::time", value)
+}
+
+// OrWhereTime compares the time of day of column with the time of value with OR logical operator
+func (q *QbDB) OrWhereTime(column, operator string, value time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+"::time "+operator+" ?::time", value)
+}
+
+// WhereBetweenDates sets the clause the date part of column BETWEEN the dates of from and to, both inclusive
+func (q *QbDB) WhereBetweenDates(column string, from, to time.Time) *QbDB {
+	return q.buildWhereClause("", column+"::date BETWEEN ?::date AND ?::date", from, to)
+}
+
+// AndWhereBetweenDates sets the clause the date part of column BETWEEN the dates of from and to, both inclusive with AND logical operator
+func (q *QbDB) AndWhereBetweenDates(column string, from, to time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+"::date BETWEEN ?::date AND ?::date", from, to)
+}
+
+// OrWhereBetweenDates sets the clause the date part of column BETWEEN the dates of from and to, both inclusive with OR logical operator
+func (q *QbDB) OrWhereBetweenDates(column string, from, to time.Time) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+"::date BETWEEN ?::date AND ?::date", from, to)
+}
+
+// WhereDateTz compares the date part of column converted to timezone with the date of value
+func (q *QbDB) WhereDateTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause("", "("+column+" AT TIME ZONE ?)::date "+operator+" ?::date", timezone, value)
+}
+
+// AndWhereDateTz compares the date part of column converted to timezone with the date of value with AND logical operator
+func (q *QbDB) AndWhereDateTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "("+column+" AT TIME ZONE ?)::date "+operator+" ?::date", timezone, value)
+}
+
+// OrWhereDateTz compares the date part of column converted to timezone with the date of value with OR logical operator
+func (q *QbDB) OrWhereDateTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "("+column+" AT TIME ZONE ?)::date "+operator+" ?::date", timezone, value)
+}
+
+// WhereYearTz compares the year of column converted to timezone with year
+func (q *QbDB) WhereYearTz(column, operator string, year int, timezone string) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(YEAR FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, year)
+}
+
+// AndWhereYearTz compares the year of column converted to timezone with year with AND logical operator
+func (q *QbDB) AndWhereYearTz(column, operator string, year int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(YEAR FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, year)
+}
+
+// OrWhereYearTz compares the year of column converted to timezone with year with OR logical operator
+func (q *QbDB) OrWhereYearTz(column, operator string, year int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(YEAR FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, year)
+}
+
+// WhereMonthTz compares the month (1-12) of column converted to timezone with month
+func (q *QbDB) WhereMonthTz(column, operator string, month int, timezone string) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(MONTH FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, month)
+}
+
+// AndWhereMonthTz compares the month (1-12) of column converted to timezone with month with AND logical operator
+func (q *QbDB) AndWhereMonthTz(column, operator string, month int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(MONTH FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, month)
+}
+
+// OrWhereMonthTz compares the month (1-12) of column converted to timezone with month with OR logical operator
+func (q *QbDB) OrWhereMonthTz(column, operator string, month int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(MONTH FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, month)
+}
+
+// WhereDayTz compares the day of month of column converted to timezone with day
+func (q *QbDB) WhereDayTz(column, operator string, day int, timezone string) *QbDB {
+	return q.buildWhereClause("", "EXTRACT(DAY FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, day)
+}
+
+// AndWhereDayTz compares the day of month of column converted to timezone with day with AND logical operator
+func (q *QbDB) AndWhereDayTz(column, operator string, day int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "EXTRACT(DAY FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, day)
+}
+
+// OrWhereDayTz compares the day of month of column converted to timezone with day with OR logical operator
+func (q *QbDB) OrWhereDayTz(column, operator string, day int, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "EXTRACT(DAY FROM "+column+" AT TIME ZONE ?) "+operator+" ?", timezone, day)
+}
+
+// WhereTimeTz compares the time of day of column converted to timezone with the time of value
+func (q *QbDB) WhereTimeTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause("", "("+column+" AT TIME ZONE ?)::time "+operator+" ?::time", timezone, value)
+}
+
+// AndWhereTimeTz compares the time of day of column converted to timezone with the time of value with AND logical operator
+func (q *QbDB) AndWhereTimeTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "("+column+" AT TIME ZONE ?)::time "+operator+" ?::time", timezone, value)
+}
+
+// OrWhereTimeTz compares the time of day of column converted to timezone with the time of value with OR logical operator
+func (q *QbDB) OrWhereTimeTz(column, operator string, value time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "("+column+" AT TIME ZONE ?)::time "+operator+" ?::time", timezone, value)
+}
+
+// WhereBetweenDatesTz sets the clause the date part of column converted to timezone BETWEEN the dates of from and to
+func (q *QbDB) WhereBetweenDatesTz(column string, from, to time.Time, timezone string) *QbDB {
+	return q.buildWhereClause("", "("+column+" AT TIME ZONE ?)::date BETWEEN ?::date AND ?::date", timezone, from, to)
+}
+
+// AndWhereBetweenDatesTz sets the clause the date part of column converted to timezone BETWEEN the dates of from and to with AND logical operator
+func (q *QbDB) AndWhereBetweenDatesTz(column string, from, to time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, "("+column+" AT TIME ZONE ?)::date BETWEEN ?::date AND ?::date", timezone, from, to)
+}
+
+// OrWhereBetweenDatesTz sets the clause the date part of column converted to timezone BETWEEN the dates of from and to with OR logical operator
+func (q *QbDB) OrWhereBetweenDatesTz(column string, from, to time.Time, timezone string) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, "("+column+" AT TIME ZONE ?)::date BETWEEN ?::date AND ?::date", timezone, from, to)
+}
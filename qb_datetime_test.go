@@ -0,0 +1,49 @@
+package qb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTimePredicates(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	runQueryCases(t, []queryCase{
+		{
+			name: "date, year, month, day, time bind typed values",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereDate("created_at", ">=", from).
+					AndWhereYear("created_at", "=", 2024).
+					OrWhereMonth("created_at", "=", 1).
+					AndWhereDay("created_at", "<", 15).
+					AndWhereTime("created_at", "<", to)
+			},
+			sql: "SELECT * FROM t WHERE 1=1  AND created_at::date >= $1::date" +
+				" AND EXTRACT(YEAR FROM created_at) = $2" +
+				" OR EXTRACT(MONTH FROM created_at) = $3" +
+				" AND EXTRACT(DAY FROM created_at) < $4" +
+				" AND created_at::time < $5::time",
+			args: []any{from, 2024, 1, 15, to},
+		},
+		{
+			name: "between dates after a plain condition",
+			build: func(q *QbDB) *QbDB {
+				return q.Where("id", "=", 1).AndWhereBetweenDates("created_at", from, to)
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND id = $1 AND created_at::date BETWEEN $2::date AND $3::date",
+			args: []any{"1", from, to},
+		},
+		{
+			name: "time zone variants bind the zone before the value",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereDateTz("created_at", "=", from, "Asia/Ho_Chi_Minh").
+					OrWhereYearTz("created_at", ">", 2020, "UTC").
+					AndWhereBetweenDatesTz("paid_at", from, to, "UTC")
+			},
+			sql: "SELECT * FROM t WHERE 1=1  AND (created_at AT TIME ZONE $1)::date = $2::date" +
+				" OR EXTRACT(YEAR FROM created_at AT TIME ZONE $3) > $4" +
+				" AND (paid_at AT TIME ZONE $5)::date BETWEEN $6::date AND $7::date",
+			args: []any{"Asia/Ho_Chi_Minh", from, "UTC", 2020, "UTC", from, to},
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package qb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// errors returned by QbDB/QbTxn methods, check them with errors.Is, ex.:
+//
+//	if errors.Is(err, qb.ErrUniqueViolation) {
+//		var qbErr *qb.QbError
+//		errors.As(err, &qbErr)
+//		log.Println(qbErr.Constraint, qbErr.Columns)
+//	}
+var (
+	ErrNotFound            = errors.New("sql: no records found")
+	ErrEmptyData           = errors.New("sql: data is empty")
+	ErrNoTable             = errors.New("sql: there was no Table() call with table name set")
+	ErrUniqueViolation     = errors.New("sql: unique violation")
+	ErrForeignKeyViolation = errors.New("sql: foreign key violation")
+	ErrNotNullViolation    = errors.New("sql: not null violation")
+	ErrCheckViolation      = errors.New("sql: check violation")
+	ErrSerialization       = errors.New("sql: serialization failure")
+	ErrDeadlock            = errors.New("sql: deadlock detected")
+	ErrTimeout             = errors.New("sql: timeout")
+)
+
+// sqlStateErrors maps SQLSTATE codes to errors
+var sqlStateErrors = map[pq.ErrorCode]error{
+	"23505":                      ErrUniqueViolation,
+	"23503":                      ErrForeignKeyViolation,
+	"23502":                      ErrNotNullViolation,
+	"23514":                      ErrCheckViolation,
+	SqlStateSerializationFailure: ErrSerialization,
+	SqlStateDeadlockDetected:     ErrDeadlock,
+	"57014":                      ErrTimeout, // query_canceled by statement_timeout
+	"55P03":                      ErrTimeout, // lock_not_available by lock_timeout
+}
+
+// detailKeyRegexp matches columns of unique/foreign key violation detail, ex.: Key (email)=(a@b.c) already exists.
+var detailKeyRegexp = regexp.MustCompile(`^Key \(([^)]*)\)=`)
+
+// QbError is the driver error of a known kind, errors.Is matches it with its Kind,
+// errors.As still gets the underlying *pq.Error
+type QbError struct {
+	Kind       error  // one of Err* errors
+	Code       string // SQLSTATE
+	Table      string
+	Constraint string
+	Columns    []string
+	Err        error // driver error
+}
+
+func (e *QbError) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Is reports whether target is the kind of error
+func (e *QbError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap gets the driver error
+func (e *QbError) Unwrap() error {
+	return e.Err
+}
+
+// mapError wraps driver error with QbError of its kind, other errors are returned as is
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var qbErr *QbError
+	if errors.As(err, &qbErr) {
+		return err
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return &QbError{Kind: ErrNotFound, Err: err}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &QbError{Kind: ErrTimeout, Err: err}
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	kind, ok := sqlStateErrors[pqErr.Code]
+	if !ok {
+		return err
+	}
+	mapped := &QbError{Kind: kind, Code: string(pqErr.Code), Table: pqErr.Table, Constraint: pqErr.Constraint, Err: err}
+	if m := detailKeyRegexp.FindStringSubmatch(pqErr.Detail); m != nil {
+		for _, column := range strings.Split(m[1], ",") {
+			mapped.Columns = append(mapped.Columns, strings.TrimSpace(column))
+		}
+	} else if IsStringNotEmpty(pqErr.Column) {
+		mapped.Columns = []string{pqErr.Column}
+	}
+	return mapped
+}
+
+// qbRow is *sql.Row mapping its Scan error
+type qbRow struct {
+	row  *sql.Row
+	err  error           // error of running query
+	done func(err error) // called with Scan error, see AfterQuery
+}
+
+// Scan copies columns of the row into dest, ErrNotFound is returned if there is no row
+func (r *qbRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	err := mapError(r.row.Scan(dest...))
+	if r.done != nil {
+		r.done(err)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package qb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		kind       error
+		code       string
+		constraint string
+		columns    []string
+	}{
+		{
+			name:       "unique violation with columns from detail",
+			err:        &pq.Error{Code: "23505", Constraint: "users_email_org_key", Detail: "Key (email, org_id)=(a@x.io, 1) already exists."},
+			kind:       ErrUniqueViolation,
+			code:       "23505",
+			constraint: "users_email_org_key",
+			columns:    []string{"email", "org_id"},
+		},
+		{
+			name:       "foreign key violation",
+			err:        &pq.Error{Code: "23503", Constraint: "orders_user_fk", Detail: `Key (user_id)=(7) is not present in table "users".`},
+			kind:       ErrForeignKeyViolation,
+			code:       "23503",
+			constraint: "orders_user_fk",
+			columns:    []string{"user_id"},
+		},
+		{name: "not null violation with column", err: &pq.Error{Code: "23502", Column: "email"}, kind: ErrNotNullViolation, code: "23502", columns: []string{"email"}},
+		{name: "check violation", err: &pq.Error{Code: "23514", Constraint: "price_positive"}, kind: ErrCheckViolation, code: "23514", constraint: "price_positive"},
+		{name: "serialization failure", err: &pq.Error{Code: "40001"}, kind: ErrSerialization, code: "40001"},
+		{name: "deadlock", err: &pq.Error{Code: "40P01"}, kind: ErrDeadlock, code: "40P01"},
+		{name: "statement timeout", err: &pq.Error{Code: "57014"}, kind: ErrTimeout, code: "57014"},
+		{name: "lock timeout", err: &pq.Error{Code: "55P03"}, kind: ErrTimeout, code: "55P03"},
+		{name: "wrapped driver error", err: fmt.Errorf("exec: %w", &pq.Error{Code: "23505"}), kind: ErrUniqueViolation, code: "23505"},
+		{name: "no rows", err: sql.ErrNoRows, kind: ErrNotFound},
+		{name: "deadline", err: context.DeadlineExceeded, kind: ErrTimeout},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := mapError(tc.err)
+			if !errors.Is(err, tc.kind) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tc.kind)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("driver error is not wrapped: %v", err)
+			}
+			var qbErr *QbError
+			if !errors.As(err, &qbErr) {
+				t.Fatalf("not a QbError: %T", err)
+			}
+			if qbErr.Code != tc.code || qbErr.Constraint != tc.constraint || !reflect.DeepEqual(qbErr.Columns, tc.columns) {
+				t.Errorf("got code %q constraint %q columns %v", qbErr.Code, qbErr.Constraint, qbErr.Columns)
+			}
+			if mapError(err) != err {
+				t.Error("mapping is not idempotent")
+			}
+		})
+	}
+}
+
+func TestMapErrorPassThrough(t *testing.T) {
+	unknown := &pq.Error{Code: "42P01"}
+	plain := errors.New("x")
+	for _, err := range []error{nil, unknown, plain} {
+		if got := mapError(err); got != err {
+			t.Errorf("mapError(%v) = %v, want as is", err, got)
+		}
+	}
+	var pqErr *pq.Error
+	if !errors.As(mapError(&pq.Error{Code: "23505"}), &pqErr) || pqErr.Code != "23505" {
+		t.Error("errors.As can't get *pq.Error")
+	}
+}
+
+func TestErrorsFromMethods(t *testing.T) {
+	fake := newFakeDB()
+	fake.fail = func(query string) error { return &pq.Error{Code: "23505", Detail: "Key (email)=(a) already exists."} }
+	db := NewQbDb(fake.conn())
+	if err := db.Table("users").Insert(map[string]any{"email": "a"}); !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("Insert err = %v", err)
+	}
+	if _, err := db.Table("users").Where("id", "=", 1).Update(map[string]any{"email": "a"}); !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("Update err = %v", err)
+	}
+	fake.fail = nil
+	if _, err := db.Table("users").First(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("First err = %v", err)
+	}
+	if err := db.Table("users").Insert(nil); err != ErrEmptyData {
+		t.Errorf("empty data err = %v", err)
+	}
+	if _, err := db.Table("").Get(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("no table err = %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package qb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Executor is the method set shared by *sql.DB, *sql.Tx and *sql.Conn, every query of QbDB runs on it
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// UseExecutor sets executor queries run on instead of the connection pool,
+// e.g. *sql.Conn to keep session settings between queries, the active transaction still takes precedence
+func (q *QbDB) UseExecutor(executor Executor) *QbDB {
+	q.executor = executor
+	return q
+}
+
+// Executor gets executor queries run on: the active transaction, the one set by UseExecutor or the connection pool
+func (q *QbDB) Executor() Executor {
+	if q.Txn != nil && q.Txn.Tx != nil {
+		return q.Txn.Tx
+	}
+	if q.executor != nil {
+		return q.executor
+	}
+	return q.Sql()
+}
+
+// connErr gets the error of opening connection pool if queries run on it
+func (q *QbDB) connErr() error {
+	if (q.Txn != nil && q.Txn.Tx != nil) || q.executor != nil || q.Conn == nil {
+		return nil
+	}
+	return q.Conn.err
+}
+
+// exec executes query without returning rows
+func (q *QbDB) exec(query string, args ...any) (sql.Result, error) {
+	setCacheExecuteStmt(query)
+	if err := q.connErr(); err != nil {
+		return nil, err
+	}
+	ctx, event := q.beforeQuery(query, args)
+	result, err := q.Executor().ExecContext(ctx, query, args...)
+	err = mapError(err)
+	var affected int64 = -1
+	if err == nil {
+		affected, _ = result.RowsAffected()
+	}
+	q.afterQuery(ctx, event, affected, err)
+	return result, err
+}
+
+// query executes query returning rows
+func (q *QbDB) query(query string, args ...any) (*sql.Rows, error) {
+	setCacheExecuteStmt(query)
+	if err := q.connErr(); err != nil {
+		return nil, err
+	}
+	ctx, event := q.beforeQuery(query, args)
+	rows, err := q.Executor().QueryContext(ctx, query, args...)
+	err = mapError(err)
+	q.afterQuery(ctx, event, -1, err)
+	return rows, err
+}
+
+// queryRow executes query expected to return at most one row
+func (q *QbDB) queryRow(query string, args ...any) *qbRow {
+	return q.queryRowOn(q.Executor(), query, args)
+}
+
+// queryRows executes query collecting rows
+func (q *QbDB) queryRows(query string, args ...any) (QbRows, error) {
+	return q.queryRowsOn(q.Executor(), query, args)
+}
+
+// readRow executes read-only query expected to return at most one row, on replica if there is one
+func (q *QbDB) readRow(query string, args ...any) *qbRow {
+	return q.queryRowOn(q.readExecutor(), query, args)
+}
+
+// readRows executes read-only query collecting rows, on replica if there is one
+func (q *QbDB) readRows(query string, args ...any) (QbRows, error) {
+	return q.queryRowsOn(q.readExecutor(), query, args)
+}
+
+func (q *QbDB) queryRowOn(executor Executor, query string, args []any) *qbRow {
+	setCacheExecuteStmt(query)
+	if err := q.connErr(); err != nil {
+		return &qbRow{err: err}
+	}
+	ctx, event := q.beforeQuery(query, args)
+	return &qbRow{
+		row: executor.QueryRowContext(ctx, query, args...),
+		done: func(err error) {
+			var affected int64 = 1
+			if err != nil {
+				affected = 0
+			}
+			q.afterQuery(ctx, event, affected, err)
+		},
+	}
+}
+
+func (q *QbDB) queryRowsOn(executor Executor, query string, args []any) (QbRows, error) {
+	setCacheExecuteStmt(query)
+	if err := q.connErr(); err != nil {
+		return nil, err
+	}
+	ctx, event := q.beforeQuery(query, args)
+	rows, err := executor.QueryContext(ctx, query, args...)
+	var collected QbRows
+	if err == nil {
+		collected, err = collectRows(rows)
+	}
+	err = mapError(err)
+	q.afterQuery(ctx, event, int64(len(collected)), err)
+	return collected, err
+}
+
+// prepare creates prepared stmt
+func (q *QbDB) prepare(query string) (*sql.Stmt, error) {
+	setCacheExecuteStmt(query)
+	if err := q.connErr(); err != nil {
+		return nil, err
+	}
+	stmt, err := q.Executor().PrepareContext(context.Background(), query)
+	return stmt, mapError(err)
+}
+
+// copyIn copies rows into table columns with COPY FROM in the active transaction,
+// hooks get one event for the whole copy with no args and the number of copied rows
+func (q *QbDB) copyIn(table string, columns []string, rows [][]any) error {
+	query := pq.CopyIn(table, columns...)
+	stmt, err := q.prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	ctx, event := q.beforeQuery(query, nil)
+	var copied int64
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			break
+		}
+		copied++
+	}
+	if err == nil {
+		_, err = stmt.ExecContext(ctx)
+	}
+	if err == nil {
+		err = stmt.Close()
+	}
+	err = mapError(err)
+	q.afterQuery(ctx, event, copied, err)
+	return err
+}
+
+// endTx commits or rolls back transaction with end running hooks around it like around query
+func (q *QbDB) endTx(query string, end func() error) error {
+	ctx, event := q.beforeQuery(query, nil)
+	err := mapError(end())
+	q.afterQuery(ctx, event, -1, err)
+	return err
+}
+
+// txBeginner is executor transaction can begin on, e.g. *sql.DB or *sql.Conn
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// beginTx starts transaction on the executor set by UseExecutor or on the connection pool running hooks around BEGIN,
+// attempt is the one of TransactionWithRetry, 0 outside of it, executor that can't begin transaction (e.g. *sql.Tx) gets error
+func (q *QbDB) beginTx(opts *sql.TxOptions, attempt int) (*sql.Tx, error) {
+	beginner, ok := q.executor.(txBeginner)
+	if q.executor != nil && !ok {
+		return nil, fmt.Errorf("sql: executor %T can't begin transaction", q.executor)
+	}
+	if q.executor == nil {
+		if err := q.connErr(); err != nil {
+			return nil, err
+		}
+		beginner = q.Sql()
+	}
+	event := q.queryEvent("BEGIN", nil)
+	if attempt > 0 {
+		event.Attempt = attempt
+	}
+	ctx, event := q.beforeEvent(event)
+	tx, err := beginner.BeginTx(ctx, opts)
+	err = mapError(err)
+	q.afterQuery(ctx, event, -1, err)
+	return tx, err
+}
@@ -0,0 +1,131 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestTransactionRoutesEveryPath(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"n"}, [][]driver.Value{{int64(1)}}
+	}
+	db := NewQbDb(fake.conn())
+	paths := []struct {
+		name string
+		run  func(tx *QbTxn) error
+	}{
+		{name: "Get", run: func(tx *QbTxn) error { _, err := tx.Table("t").Get(); return err }},
+		{name: "First", run: func(tx *QbTxn) error { _, err := tx.Table("t").First(); return err }},
+		{name: "Count", run: func(tx *QbTxn) error { _, err := tx.Table("t").Count(); return err }},
+		{name: "Exists", run: func(tx *QbTxn) error { _, err := tx.Table("t").Exists(); return err }},
+		{name: "Avg", run: func(tx *QbTxn) error { _, err := tx.Table("t").Avg("n"); return err }},
+		{name: "HasTable", run: func(tx *QbTxn) error { _, err := tx.HasTable("public", "t"); return err }},
+		{name: "Increment", run: func(tx *QbTxn) error { _, err := tx.Table("t").Where("id", "=", 1).Increment("n", 1); return err }},
+		{name: "InsertMany", run: func(tx *QbTxn) error { _, err := tx.Table("t").InsertMany([]map[string]any{{"n": 1}}); return err }},
+		{name: "InsertBatch", run: func(tx *QbTxn) error { return tx.Table("t").InsertBatch([]map[string]any{{"n": 1}}) }},
+	}
+	err := db.Transaction(func(tx *QbTxn) error {
+		for _, path := range paths {
+			if err := path.run(tx); err != nil {
+				t.Errorf("%s: %v", path.name, err)
+			}
+		}
+		// a query on the pool while tx is open takes another connection
+		_, err := NewQbDb(db.Conn).Table("outside").Get()
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txConn := fake.queries[0].conn
+	for _, query := range fake.queries {
+		inside := query.sql != "SELECT * FROM outside"
+		if inside != (query.conn == txConn) {
+			t.Errorf("%q ran on conn %d, transaction is on conn %d", query.sql, query.conn, txConn)
+		}
+	}
+}
+
+func TestUseExecutor(t *testing.T) {
+	fake := newFakeDB()
+	db := NewQbDb(fake.conn())
+	tx, err := db.Sql().Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := NewQbDb(db.Conn).UseExecutor(tx).Table("t").Where("id", "=", 1).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if begin, del := fake.queries[0], fake.queries[1]; begin.conn != del.conn || del.sql != `DELETE FROM "t" WHERE 1=1  AND id = $1` {
+		t.Errorf("queries = %+v", fake.queries)
+	}
+}
+
+func TestUseExecutorTxKeepsStatementsInCallerTransaction(t *testing.T) {
+	many := make([]map[string]any, MaxBindings+1)
+	for i := range many {
+		many[i] = map[string]any{"id": i, "n": i}
+	}
+	tests := []struct {
+		name string
+		run  func(q *QbDB) error
+	}{
+		{name: "InsertMany", run: func(q *QbDB) error { _, err := q.Table("t").InsertMany(many); return err }},
+		{name: "InsertBatch", run: func(q *QbDB) error { return q.Table("t").InsertBatch(many[:2]) }},
+		{name: "UpdateBatch", run: func(q *QbDB) error { _, err := q.Table("t").UpdateBatch("id", many[:2]); return err }},
+		{name: "Upsert ExecMany", run: func(q *QbDB) error {
+			_, err := q.Table("t").Upsert().OnConflict("id").ExecMany(many)
+			return err
+		}},
+		{name: "Returning InsertMany", run: func(q *QbDB) error { _, err := q.Table("t").Returning().InsertMany(many); return err }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			fake.rows = func(query string) ([]string, [][]driver.Value) {
+				if strings.Contains(query, "pg_attribute") {
+					return []string{"attname", "format_type"}, [][]driver.Value{{"id", "integer"}, {"n", "integer"}}
+				}
+				return nil, nil
+			}
+			conn := fake.conn()
+			tx, err := NewQbDb(conn).Sql().Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = tc.run(NewQbDb(conn).UseExecutor(tx)); err != nil {
+				t.Fatal(err)
+			}
+			if err = tx.Rollback(); err != nil {
+				t.Fatal(err)
+			}
+			txConn := fake.queries[0].conn
+			for i, query := range fake.queries {
+				if query.conn != txConn {
+					t.Errorf("%q ran on conn %d, want the tx conn %d", query.sql, query.conn, txConn)
+				}
+				if query.sql == "BEGIN" && i > 0 || query.sql == "COMMIT" {
+					t.Errorf("%q ran in the caller transaction", query.sql)
+				}
+			}
+			if last := fake.queries[len(fake.queries)-1].sql; last != "ROLLBACK" {
+				t.Errorf("last statement %q, want ROLLBACK", last)
+			}
+		})
+	}
+}
+
+func TestBeginOnExecutorWithoutBeginTx(t *testing.T) {
+	conn := newFakeDB().conn()
+	tx, err := NewQbDb(conn).Sql().Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := NewQbDb(conn).UseExecutor(tx).Begin(nil); err == nil || !strings.Contains(err.Error(), "can't begin transaction") {
+		t.Errorf("err = %v, want executor error", err)
+	}
+}
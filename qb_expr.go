@@ -0,0 +1,35 @@
+package qb
+
+// QbExpr is a raw sql expression rendered inline instead of being bound as a value,
+// its own ? placeholders are bound to args and merged into the placeholder sequence of the query
+type QbExpr struct {
+	sql  string
+	args []any
+}
+
+// Expr creates raw sql expression to be used as a value of Insert/Update/Replace maps, QbOps fields,
+// Where values and Case branches, ex.:
+//
+//	db.Table("posts").Where("id", "=", 5).Update(map[string]any{
+//		"views":      qb.Expr("views + ?", 1),
+//		"updated_at": qb.Expr("NOW()"),
+//	})
+//
+// use ?? to put a literal ? into expression (e.g. jsonb operators)
+func Expr(sql string, args ...any) *QbExpr {
+	return &QbExpr{sql: sql, args: args}
+}
+
+// String gets raw sql of expression with ? placeholders
+func (e *QbExpr) String() string {
+	return e.sql
+}
+
+// Args gets values bound to ? placeholders of expression
+func (e *QbExpr) Args() []any {
+	return e.args
+}
+
+func (e *QbExpr) clause() *qbClause {
+	return &qbClause{sql: e.sql, args: e.args}
+}
@@ -0,0 +1,92 @@
+package qb
+
+import "testing"
+
+func TestExprInWhere(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name: "expression value is rendered inline with its own bindings",
+			build: func(q *QbDB) *QbDB {
+				return q.Where("id", "=", 1).AndWhere("expires_at", "<", Expr("NOW() - ?::interval", "1 day")).OrWhere("name", "=", "x")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND id = $1 AND expires_at < NOW() - $2::interval OR name = $3",
+			args: []any{"1", "1 day", "x"},
+		},
+		{
+			name:  "double question mark is a literal",
+			build: func(q *QbDB) *QbDB { return q.Where("data", "=", Expr("data - ? || '{}' ?? 'a'", "k")) },
+			sql:   "SELECT * FROM t WHERE 1=1  AND data = data - $1 || '{}' ? 'a'",
+			args:  []any{"k"},
+		},
+	})
+}
+
+func TestExprInDataMaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		compose func(b *qbBuilder) (string, []any)
+		sql     string
+		args    []any
+	}{
+		{
+			name: "insert",
+			compose: func(b *qbBuilder) (string, []any) {
+				query, args, _ := b.composeInsert(map[string]any{"created_at": Expr("NOW()")})
+				return query, args
+			},
+			sql: `INSERT INTO "t" (created_at) VALUES(NOW())`,
+		},
+		{
+			name: "update merges expression bindings before where ones",
+			compose: func(b *qbBuilder) (string, []any) {
+				b.whereBindings = []map[string]any{{"id =": 5}}
+				query, args, _, _ := b.composeUpdate(map[string]any{"views": Expr("views + ?", 2)})
+				return query, args
+			},
+			sql:  `UPDATE "t" SET views = views + $1 WHERE 1=1  AND id = $2`,
+			args: []any{2, "5"},
+		},
+		{
+			name: "replace",
+			compose: func(b *qbBuilder) (string, []any) {
+				query, args, _, _ := b.composeReplace(map[string]any{"tags": Expr("array_append(tags, ?)", "new")}, "id")
+				return query, args
+			},
+			sql:  `INSERT INTO "t" (tags) VALUES(array_append(tags, $1)) ON CONFLICT(id) DO UPDATE SET tags = excluded.tags`,
+			args: []any{"new"},
+		},
+		{
+			name: "insert many fills DEFAULT around expressions",
+			compose: func(b *qbBuilder) (string, []any) {
+				rows := []map[string]any{{"a": Expr("lower(?)", "X"), "b": 1}, {"b": Expr("? + ?", 1, 2)}}
+				query, args, _ := b.composeInsertMany(unionColumns(rows), rows)
+				return query, args
+			},
+			sql:  `INSERT INTO "t" (a, b) VALUES (lower($1), $2), (DEFAULT, $3 + $4)`,
+			args: []any{"X", "1", 1, 2},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newTestDB().Table("t")
+			query, args := tc.compose(q.Builder)
+			if query != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", query, tc.sql)
+			}
+			assertArgs(t, args, tc.args)
+		})
+	}
+}
+
+func TestQbOpsSetFieldExpr(t *testing.T) {
+	ops := NewQbOps().SetField(func() bool { return true }, "updated_at", Expr("NOW()")).
+		SetField(func() bool { return false }, "skipped", 1)
+	query, args, _, err := newTestDB().Table("t").Where("id", "=", 1).Builder.composeUpdate(ops.GetArgs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `UPDATE "t" SET updated_at = NOW() WHERE 1=1  AND id = $1`; query != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", query, want)
+	}
+	assertArgs(t, args, []any{"1"})
+}
@@ -1,6 +1,7 @@
 package qb
 
 import (
+	"database/sql"
 	"fmt"
 )
 
@@ -8,7 +9,10 @@ import (
 func (q *QbDB) Get() ([]map[string]any, error) {
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return nil, errTableCallBeforeOp
+		return nil, ErrNoTable
+	}
+	if builder.err != nil {
+		return nil, builder.err
 	}
 	query := ""
 	if len(builder.union) > 0 { // got union - need different logic to glue
@@ -30,11 +34,15 @@ func (q *QbDB) Get() ([]map[string]any, error) {
 	} else {
 		query = builder.buildSelect()
 	}
-	rows, err := q.Sql().Query(query, prepareValues(q.Builder.whereBindings)...)
-	if err != nil {
-		return nil, err
-	}
+	args, columns := q.Builder.selectBoundArgs()
+	return q.bound(columns).readRows(query, args...)
+}
+
+// collectRows scans all rows to the slice of column-value maps and closes them
+func collectRows(rows *sql.Rows) ([]map[string]any, error) {
+	defer rows.Close()
 	columns, _ := rows.Columns()
+	columnTypes, _ := rows.ColumnTypes()
 	count := len(columns)
 	values := make([]any, count)
 	valuesCount := make([]any, count)
@@ -48,7 +56,7 @@ func (q *QbDB) Get() ([]map[string]any, error) {
 		}
 		err := rows.Scan(valuesCount...)
 		if err != nil {
-			return nil, err
+			return nil, mapError(err)
 		}
 		for i, col := range columns {
 			val := values[i]
@@ -58,10 +66,13 @@ func (q *QbDB) Get() ([]map[string]any, error) {
 			} else {
 				collect[col] = val
 			}
+			if i < len(columnTypes) {
+				collect[col] = decodeGeometry(columnTypes[i].DatabaseTypeName(), collect[col])
+			}
 		}
 		response = append(response, collect)
 	}
-	return response, nil
+	return response, mapError(rows.Err())
 }
 
 // First getting the 1st row of query
@@ -73,7 +84,7 @@ func (q *QbDB) First() (map[string]interface{}, error) {
 	if len(result) > 0 {
 		return result[0], nil
 	}
-	return nil, fmt.Errorf("no records were produced by query: %s", q.GetQuery())
+	return nil, fmt.Errorf("%w: no records were produced by query: %s", ErrNotFound, q.GetQuery())
 }
 
 // Value gets the value of column in first query resulting row
@@ -89,9 +100,10 @@ func (q *QbDB) Value(column string) (value interface{}, err error) {
 	return
 }
 
-// Find retrieves a single row by it's id column value
+// Find retrieves a single row by it's id column value,
+// the key column is resolved by Key/SetPrimaryKey and defaults to id
 func (q *QbDB) Find(id uint64) (map[string]interface{}, error) {
-	return q.Where("id", "=", id).First()
+	return FindBy(q, id)
 }
 
 // Pull getting values of a particular column and place them into slice
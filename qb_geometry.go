@@ -0,0 +1,221 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// QbPoint is the Go type for PostgreSQL point, binds on insert and decodes on Get
+type QbPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// QbPolygon is the Go type for PostgreSQL polygon as an ordered list of vertices
+type QbPolygon []QbPoint
+
+// NewQbPoint creates point from x, y coordinates
+func NewQbPoint(x, y float64) QbPoint {
+	return QbPoint{X: x, Y: y}
+}
+
+// NewQbPolygon creates polygon from vertices
+func NewQbPolygon(points ...QbPoint) QbPolygon {
+	return QbPolygon(points)
+}
+
+// String renders point in PostgreSQL text format (x,y)
+func (p QbPoint) String() string {
+	return "(" + formatFloat(p.X) + "," + formatFloat(p.Y) + ")"
+}
+
+// Value implements driver.Valuer
+func (p QbPoint) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements sql.Scanner, NULL gives zero point (use *QbPoint field to tell NULL apart)
+func (p *QbPoint) Scan(src any) error {
+	if src == nil {
+		*p = QbPoint{}
+		return nil
+	}
+	s, err := geometryText(src)
+	if err != nil {
+		return err
+	}
+	point, err := parsePoint(s)
+	if err != nil {
+		return err
+	}
+	*p = point
+	return nil
+}
+
+// String renders polygon in PostgreSQL text format ((x1,y1),(x2,y2),...)
+func (p QbPolygon) String() string {
+	points := make([]string, len(p))
+	for i, point := range p {
+		points[i] = point.String()
+	}
+	return "(" + strings.Join(points, ",") + ")"
+}
+
+// Value implements driver.Valuer
+func (p QbPolygon) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	return p.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (p *QbPolygon) Scan(src any) error {
+	if src == nil {
+		*p = nil
+		return nil
+	}
+	s, err := geometryText(src)
+	if err != nil {
+		return err
+	}
+	polygon, err := parsePolygon(s)
+	if err != nil {
+		return err
+	}
+	*p = polygon
+	return nil
+}
+
+// WherePointInPolygon appends point column <@ polygon stmt to WHERE clause
+func (q *QbDB) WherePointInPolygon(column string, polygon QbPolygon) *QbDB {
+	return q.buildWhereClause("", column+" "+SqlOperatorGeoContainedBy+" ?::polygon", polygon)
+}
+
+// AndWherePointInPolygon appends point column <@ polygon stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWherePointInPolygon(column string, polygon QbPolygon) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+" "+SqlOperatorGeoContainedBy+" ?::polygon", polygon)
+}
+
+// OrWherePointInPolygon appends point column <@ polygon stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWherePointInPolygon(column string, polygon QbPolygon) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+" "+SqlOperatorGeoContainedBy+" ?::polygon", polygon)
+}
+
+// WherePolygonContains appends polygon column @> point stmt to WHERE clause
+func (q *QbDB) WherePolygonContains(column string, point QbPoint) *QbDB {
+	return q.buildWhereClause("", column+" "+SqlOperatorGeoContains+" ?::point", point)
+}
+
+// AndWherePolygonContains appends polygon column @> point stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWherePolygonContains(column string, point QbPoint) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+" "+SqlOperatorGeoContains+" ?::point", point)
+}
+
+// OrWherePolygonContains appends polygon column @> point stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWherePolygonContains(column string, point QbPoint) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+" "+SqlOperatorGeoContains+" ?::point", point)
+}
+
+// WhereDistanceWithin appends column <-> point <= radius stmt to WHERE clause
+func (q *QbDB) WhereDistanceWithin(column string, point QbPoint, radius float64) *QbDB {
+	return q.buildWhereClause("", column+" "+SqlOperatorGeoDistance+" ?::point <= ?", point, radius)
+}
+
+// AndWhereDistanceWithin appends column <-> point <= radius stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereDistanceWithin(column string, point QbPoint, radius float64) *QbDB {
+	return q.buildWhereClause(SqlOperatorAnd, column+" "+SqlOperatorGeoDistance+" ?::point <= ?", point, radius)
+}
+
+// OrWhereDistanceWithin appends column <-> point <= radius stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereDistanceWithin(column string, point QbPoint, radius float64) *QbDB {
+	return q.buildWhereClause(SqlOperatorOr, column+" "+SqlOperatorGeoDistance+" ?::point <= ?", point, radius)
+}
+
+// OrderByDistance adds ORDER BY column <-> point expression, nearest rows go first
+// point coordinates are rendered as numeric literals, so there are no bindings to shift,
+// NaN or infinite coordinates are returned as error by the query
+func (q *QbDB) OrderByDistance(column string, point QbPoint) *QbDB {
+	if !point.isFinite() {
+		q.Builder.setErr(fmt.Errorf("sql: invalid point %s for distance order", point))
+		return q
+	}
+	return q.OrderBy(column+" "+SqlOperatorGeoDistance+" point"+point.String(), "ASC")
+}
+
+// isFinite reports whether coordinates are neither NaN nor infinite
+func (p QbPoint) isFinite() bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// decodes point/polygon typed column values collected by Get
+func decodeGeometry(dbType string, value any) any {
+	if value == nil {
+		return nil
+	}
+	switch dbType {
+	case TypePoint:
+		var p QbPoint
+		if err := p.Scan(value); err == nil {
+			return p
+		}
+	case TypePolygon:
+		var p QbPolygon
+		if err := p.Scan(value); err == nil {
+			return p
+		}
+	}
+	return value
+}
+
+func geometryText(src any) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("sql: unsupported geometry source type %T", src)
+}
+
+func parsePoint(s string) (QbPoint, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return QbPoint{}, fmt.Errorf("sql: invalid point %q", s)
+	}
+	x, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return QbPoint{}, err
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return QbPoint{}, err
+	}
+	return QbPoint{X: x, Y: y}, nil
+}
+
+func parsePolygon(s string) (QbPolygon, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	if IsStringEmpty(s) {
+		return QbPolygon{}, nil
+	}
+	var polygon QbPolygon
+	for _, raw := range strings.Split(s, "),(") {
+		point, err := parsePoint(raw)
+		if err != nil {
+			return nil, err
+		}
+		polygon = append(polygon, point)
+	}
+	return polygon, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,102 @@
+package qb
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGeometryPredicates(t *testing.T) {
+	polygon := NewQbPolygon(NewQbPoint(0, 0), NewQbPoint(0, 1.5), NewQbPoint(1, 1))
+	runQueryCases(t, []queryCase{
+		{
+			name: "point in polygon, polygon contains, distance within, order by distance",
+			build: func(q *QbDB) *QbDB {
+				return q.WherePointInPolygon("loc", polygon).
+					OrWherePolygonContains("area", NewQbPoint(1, 2)).
+					AndWhereDistanceWithin("loc", NewQbPoint(3, 4), 10).
+					OrderByDistance("loc", NewQbPoint(1, 2))
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND loc <@ $1::polygon OR area @> $2::point AND loc <-> $3::point <= $4 ORDER BY loc <-> point(1,2) ASC",
+			args: []any{polygon, NewQbPoint(1, 2), NewQbPoint(3, 4), 10.0},
+		},
+	})
+}
+
+func TestQbPointScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    QbPoint
+		wantErr bool
+	}{
+		{name: "text", src: "(1.5,-2)", want: QbPoint{X: 1.5, Y: -2}},
+		{name: "bytes", src: []byte("(3,4)"), want: QbPoint{X: 3, Y: 4}},
+		{name: "null", src: nil, want: QbPoint{}},
+		{name: "invalid", src: "(1)", wantErr: true},
+		{name: "unsupported type", src: 42, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := QbPoint{X: 9, Y: 9}
+			err := p.Scan(tc.src)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && p != tc.want {
+				t.Errorf("got %v, want %v", p, tc.want)
+			}
+		})
+	}
+}
+
+func TestQbPolygonScanAndValue(t *testing.T) {
+	var p QbPolygon
+	if err := p.Scan("((0,0),(1,1),(1,0))"); err != nil {
+		t.Fatal(err)
+	}
+	want := NewQbPolygon(NewQbPoint(0, 0), NewQbPoint(1, 1), NewQbPoint(1, 0))
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("got %v, want %v", p, want)
+	}
+	if v, _ := p.Value(); v != "((0,0),(1,1),(1,0))" {
+		t.Errorf("value = %v", v)
+	}
+	if err := p.Scan(nil); err != nil || p != nil {
+		t.Errorf("null scan = %v, %v", p, err)
+	}
+}
+
+func TestDecodeGeometry(t *testing.T) {
+	if got := decodeGeometry(TypePoint, "(1,2)"); got != NewQbPoint(1, 2) {
+		t.Errorf("point = %#v", got)
+	}
+	if got := decodeGeometry("TEXT", "(1,2)"); got != "(1,2)" {
+		t.Errorf("text = %#v", got)
+	}
+}
+
+func TestOrderByDistanceRejectsNonFinite(t *testing.T) {
+	tests := []struct {
+		name    string
+		point   QbPoint
+		wantErr bool
+	}{
+		{name: "finite", point: NewQbPoint(1, -2.5)},
+		{name: "NaN x", point: NewQbPoint(math.NaN(), 0), wantErr: true},
+		{name: "+Inf y", point: NewQbPoint(0, math.Inf(1)), wantErr: true},
+		{name: "-Inf x", point: NewQbPoint(math.Inf(-1), 0), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			_, err := NewQbDb(fake.conn()).Table("t").OrderByDistance("loc", tc.point).Get()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr && len(fake.statements()) != 0 {
+				t.Errorf("ran %v", fake.statements())
+			}
+		})
+	}
+}
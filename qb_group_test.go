@@ -0,0 +1,123 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestGroupByAndHaving(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name: "rollup with having on an expression containing DISTINCT",
+			build: func(q *QbDB) *QbDB {
+				return q.Select("region", "count(DISTINCT user_id) AS users").
+					GroupByRollup("region").
+					Having("count(DISTINCT user_id)", ">", 5)
+			},
+			sql:  "SELECT region, count(DISTINCT user_id) AS users FROM t GROUP BY ROLLUP (region) HAVING 1=1 AND count(DISTINCT user_id) > $1",
+			args: []any{"5"},
+		},
+		{
+			name: "having bindings continue where numbering and precede order",
+			build: func(q *QbDB) *QbDB {
+				return q.Select("region").
+					Where("year", "=", 2024).
+					GroupBy("region", "city").
+					Having("sum(amount)", ">", 1000).
+					OrHaving("count(*)", "IS", "NOT NULL").
+					AndHaving("max(amount)", "<", 2.5).
+					OrderByCase(Case().When("region = ?", 1, "EU").Else(2), "DESC")
+			},
+			sql: "SELECT region FROM t WHERE 1=1  AND year = $1 GROUP BY region, city" +
+				" HAVING 1=1 AND sum(amount) > $2 OR count(*) IS NOT NULL AND max(amount) < $3 ORDER BY CASE WHEN region = $4 THEN $5::bigint ELSE $6::bigint END DESC",
+			args: []any{"2024", "1000", "2.5", "EU", 1, 2},
+		},
+		{
+			name: "non string value with BETWEEN in operand is bound",
+			build: func(q *QbDB) *QbDB {
+				return q.GroupBy("kind").Having("sum(CASE WHEN x BETWEEN 1 AND 2 THEN 1 END)", "=", 3)
+			},
+			sql:  "SELECT * FROM t GROUP BY kind HAVING 1=1 AND sum(CASE WHEN x BETWEEN 1 AND 2 THEN 1 END) = $1",
+			args: []any{"3"},
+		},
+		{
+			name: "cube and grouping sets",
+			build: func(q *QbDB) *QbDB {
+				return q.GroupByCube("a", "b").GroupingSets([]string{"c", "d"}, []string{"c"}, nil)
+			},
+			sql: "SELECT * FROM t GROUP BY CUBE (a, b), GROUPING SETS ((c, d), (c), ())",
+		},
+		{
+			name: "where IS NULL and BETWEEN stay inline",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereNull("deleted_at").AndWhereBetween("age", 18, 30).AndWhere("name", "=", "x")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND deleted_at IS NULL AND age BETWEEN 18 AND 30 AND name = $1",
+			args: []any{"x"},
+		},
+	})
+}
+
+func TestGroupKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []any
+		want  string
+	}{
+		{name: "values", parts: []any{"EU", 2024}, want: "EU|2024"},
+		{name: "rolled up", parts: []any{"EU", GroupAll}, want: "EU|*"},
+		{name: "literal star", parts: []any{"*", 1}, want: `\*|1`},
+		{name: "null", parts: []any{nil, "x"}, want: `\N|x`},
+		{name: "literal backslash N", parts: []any{`\N`}, want: `\\N`},
+		{name: "separator in value", parts: []any{"a|b"}, want: `a\|b`},
+		{name: "bytes", parts: []any{[]byte("b|c")}, want: `b\|c`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := GroupKey(tc.parts...); got != tc.want {
+				t.Errorf("GroupKey(%v) = %q, want %q", tc.parts, got, tc.want)
+			}
+		})
+	}
+	distinct := [][]any{{"a|b", "c"}, {"a", "b|c"}, {"*"}, {GroupAll}, {nil}, {"<nil>"}, {`\N`}}
+	seen := make(map[string]bool)
+	for _, parts := range distinct {
+		key := GroupKey(parts...)
+		if seen[key] {
+			t.Errorf("GroupKey(%v) = %q collides", parts, key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestGetGrouped(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"region", "total", "__grouping_0"}, [][]driver.Value{
+			{"EU", int64(10), int64(0)},
+			{nil, int64(4), int64(0)},
+			{nil, int64(14), int64(1)},
+		}
+	}
+	rows, err := NewQbDb(fake.conn()).Table("sales").Select("region", "sum(amount) AS total").
+		GroupByRollup("region").GetGrouped("region")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT region, sum(amount) AS total, GROUPING(region) AS "__grouping_0" FROM sales GROUP BY ROLLUP (region)`
+	if got := fake.statements()[0]; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("rows = %v", rows)
+	}
+	if row := rows[GroupKey("EU")]; row == nil || row.Values["total"] != int64(10) {
+		t.Errorf("EU row = %v", row)
+	}
+	if row := rows[GroupKey(nil)]; row == nil || row.Grouping[0] || row.Values["total"] != int64(4) {
+		t.Errorf("NULL region row = %v", row)
+	}
+	if row := rows[GroupKey(GroupAll)]; row == nil || !row.Grouping[0] || row.Values["total"] != int64(14) {
+		t.Errorf("total row = %v", row)
+	}
+}
@@ -1,13 +1,17 @@
 package qb
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 func IsStringEmpty(s string) bool {
@@ -47,13 +51,49 @@ func Interface2Slice(slice interface{}) ([]interface{}, error) {
 	return v, err
 }
 
-func prepareValues(values []map[string]any) []any {
+// prepareBoundValues prepares values of conditions with the columns they are compared to, see conditionColumn
+func prepareBoundValues(values []map[string]any) ([]any, []string) {
 	var result []any
-	for _, v := range values {
-		_, val, _ := prepareBindings(v)
-		result = append(result, val...)
+	var columns []string
+	for _, m := range values {
+		for column, value := range m {
+			var bound []any
+			if expr, ok := value.(qbExpression); ok {
+				bound = prepareClauseArgs(expr.clause())
+			} else if !isInlineCondition(column, value) {
+				bound = prepareValue(value)
+			}
+			result = append(result, bound...)
+			columns = append(columns, repeatColumn(conditionColumn(column), len(bound))...)
+		}
+	}
+	return result, columns
+}
+
+// conditionColumn gets column of condition key "[AND|OR] operand operator", "" if operand is not a column
+func conditionColumn(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) > 0 && (strings.EqualFold(fields[0], SqlOperatorAnd) || strings.EqualFold(fields[0], SqlOperatorOr)) {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	for _, part := range strings.Split(fields[0], ".") {
+		if !identifierRegexp.MatchString(strings.Trim(part, `"`)) {
+			return ""
+		}
+	}
+	return unqualifiedColumn(fields[0])
+}
+
+// repeatColumn gets column repeated for each of n values bound to it
+func repeatColumn(column string, n int) []string {
+	columns := make([]string, n)
+	for i := range columns {
+		columns[i] = column
 	}
-	return result
+	return columns
 }
 
 func prepareValue(value any) []any {
@@ -79,61 +119,134 @@ func prepareValue(value any) []any {
 		}
 	case nil:
 		values = append(values, nil)
+	default:
+		values = append(values, prepareArg(v))
+	}
+	return values
+}
+
+// prepareClauseArgs prepares values bound to ? placeholders of the clause
+func prepareClauseArgs(clause *qbClause) []any {
+	values := make([]any, len(clause.args))
+	for i, arg := range clause.args {
+		values[i] = prepareArg(arg)
 	}
 	return values
 }
 
+// prepareArg prepares a single bound value, Go slices are bound as one PostgreSQL array parameter
+func prepareArg(value any) any {
+	if isArrayValue(value) {
+		return pq.Array(value)
+	}
+	return value
+}
+
+// isArrayValue determines whether value is a Go slice/array which has to be bound as PostgreSQL array
+func isArrayValue(value any) bool {
+	if value == nil {
+		return false
+	}
+	switch value.(type) {
+	case []byte, driver.Valuer:
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
 // prepareBindings prepares slices to split in favor of INSERT sql statement
-func prepareBindings(data map[string]any) (columns []string, values []any, bindings []string) {
+// argColumns are the columns values are bound to
+func prepareBindings(data map[string]any) (columns []string, values []any, bindings []string, argColumns []string) {
 	i := 1
 	for column, value := range data {
 		if strings.Contains(column, SqlOperatorIs) || strings.Contains(column, SqlOperatorBetween) {
 			continue
 		}
 		columns = append(columns, column)
-		pValues := prepareValue(value)
-		if len(pValues) > 0 {
-			values = append(values, pValues...)
-			for range pValues {
-				bindings = append(bindings, fmt.Sprintf("%s%s", "$", strconv.FormatInt(int64(i), 10)))
-				i++
+		binding, pValues, next := bindValue(value, i)
+		bindings = append(bindings, binding)
+		values = append(values, pValues...)
+		argColumns = append(argColumns, repeatColumn(unqualifiedColumn(column), len(pValues))...)
+		i = next
+	}
+	return
+}
+
+// bindValue renders a single column value as $i binding, expressions are rendered inline with their own bindings,
+// returns binding, bound values and the next free binding index
+func bindValue(value any, i int) (string, []any, int) {
+	if expr, ok := value.(qbExpression); ok {
+		clause := expr.clause()
+		binding, next := renderPlaceholders(clause.sql, i)
+		return binding, prepareClauseArgs(clause), next
+	}
+	if v, ok := value.([]any); ok { // the whole slice goes to an array column, not to IN list
+		value = pq.Array(v)
+	}
+	return fmt.Sprintf("%s%s", "$", strconv.FormatInt(int64(i), 10)), prepareValue(value), i + 1
+}
+
+// unionColumns collects the sorted union of keys of all rows, so every row maps to the same column list
+func unionColumns(rows []map[string]any) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
 			}
 		}
 	}
-	return
+	sort.Strings(columns)
+	return columns
 }
 
-// prepareInsertBatch prepares slices to split in favor of INSERT sql statement
+// prepareInsertBatch prepares slices to split in favor of COPY FROM stmt,
+// columns are the union of all rows keys and values of each row follow their order
 func prepareInsertBatch(data []map[string]any) (columns []string, values [][]any) {
+	columns = unionColumns(data)
 	values = make([][]any, len(data))
-	columnToIdx := make(map[string]int)
-	i := 0
-	for k, v := range data {
-		values[k] = make([]any, len(v))
-		for column, value := range v {
-			if k == 0 {
-				columns = append(columns, column)
-				// todo: don't know yet how to match them explicitly (it is bad idea, but it works well now)
-				columnToIdx[column] = i
-				i++
-			}
-			switch casted := value.(type) {
-			case string:
-				values[k][columnToIdx[column]] = casted
-			case int:
-				values[k][columnToIdx[column]] = strconv.FormatInt(int64(casted), 10)
-			case float64:
-				values[k][columnToIdx[column]] = fmt.Sprintf("%g", casted)
-			case int64:
-				values[k][columnToIdx[column]] = strconv.FormatInt(casted, 10)
-			case uint64:
-				values[k][columnToIdx[column]] = strconv.FormatUint(casted, 10)
+	for k, row := range data {
+		values[k] = make([]any, len(columns))
+		for c, column := range columns {
+			if value, ok := row[column]; ok {
+				values[k][c] = prepareArg(value)
 			}
 		}
 	}
 	return
 }
 
+// chunkRows splits rows so that bindings of every chunk stay under limit
+func chunkRows(columns []string, rows []map[string]any, limit int) [][]map[string]any {
+	var chunks [][]map[string]any
+	start, count := 0, 0
+	for k, row := range rows {
+		n := 0
+		for _, column := range columns {
+			if value, ok := row[column]; ok {
+				n += countBindings(value)
+			}
+		}
+		if count+n > limit && k > start {
+			chunks = append(chunks, rows[start:k])
+			start, count = k, 0
+		}
+		count += n
+	}
+	return append(chunks, rows[start:])
+}
+
+// countBindings counts bindings a single column value takes in stmt
+func countBindings(value any) int {
+	if expr, ok := value.(qbExpression); ok {
+		return len(expr.clause().args)
+	}
+	return 1
+}
+
 func transform2String(value any) string {
 	switch v := value.(type) {
 	case string:
@@ -170,13 +283,29 @@ func prepareSlice(in []any) (out []string) {
 	return
 }
 
-// composes WHERE clause string for particular query stmt
-func composeWhere(whereBindings []map[string]any, startedAt int) string {
-	where := " WHERE 1=1 " // where any level tables, combine with any condition
+// composes WHERE clause string for particular query stmt, returns the next free binding index
+func composeWhere(whereBindings []map[string]any, startedAt int) (string, int) {
+	conditions, next := composeConditions(whereBindings, startedAt)
+	return " WHERE 1=1 " + conditions, next // where any level tables, combine with any condition
+}
+
+// composes bound conditions joined by their logical operators, returns the next free binding index
+func composeConditions(bindings []map[string]any, startedAt int) (string, int) {
+	where := ""
 	i := startedAt
-	for _, m := range whereBindings {
+	for _, m := range bindings {
 		for k, v := range m {
+			if !strings.HasPrefix(k, " ") { // the very first condition has no logical operator
+				k = And + k
+			}
 			switch vi := v.(type) {
+			case qbExpression:
+				var clause string
+				clause, i = renderPlaceholders(vi.clause().sql, i)
+				if !strings.HasSuffix(k, " ") { // operand operator followed by expression, ex.: expires_at < NOW()
+					k += " "
+				}
+				where += k + clause
 			case []any:
 				placeholders := make([]string, 0, len(vi))
 				for range vi {
@@ -185,7 +314,7 @@ func composeWhere(whereBindings []map[string]any, startedAt int) string {
 				}
 				where += k + " (" + strings.Join(placeholders, ", ") + ")"
 			default:
-				if strings.Contains(k, SqlOperatorIs) || strings.Contains(k, SqlOperatorBetween) {
+				if isInlineCondition(k, vi) {
 					where += k + " " + vi.(string)
 					break
 				}
@@ -194,15 +323,52 @@ func composeWhere(whereBindings []map[string]any, startedAt int) string {
 			}
 		}
 	}
-	return where
+	return where, i
+}
+
+// isInlineCondition determines whether condition value is rendered as is instead of being bound,
+// that is the string operand of IS (NULL, NOT NULL) and [NOT] BETWEEN (a AND b) operators,
+// the operator is taken from the end of key, so operands like count(DISTINCT id) are bound as usual
+func isInlineCondition(key string, value any) bool {
+	if _, ok := value.(string); !ok {
+		return false
+	}
+	key = strings.ToUpper(key)
+	return strings.HasSuffix(key, " "+SqlOperatorIs) || strings.HasSuffix(key, " "+SqlOperatorBetween)
+}
+
+// renderPlaceholders replaces each ? in raw sql with positional $n bindings starting at startedAt,
+// ?? is kept as a literal ? (e.g. for jsonb operators), returns sql and the next free binding index
+func renderPlaceholders(raw string, startedAt int) (string, int) {
+	var sb strings.Builder
+	i := startedAt
+	for k := 0; k < len(raw); k++ {
+		if raw[k] != '?' {
+			sb.WriteByte(raw[k])
+			continue
+		}
+		if k+1 < len(raw) && raw[k+1] == '?' {
+			sb.WriteByte('?')
+			k++
+			continue
+		}
+		sb.WriteString("$" + strconv.Itoa(i))
+		i++
+	}
+	return sb.String(), i
 }
 
 // composers ORDER BY clause string for particular query stmt
-func composeOrderBy(orderBy []map[string]string, orderByRaw *string) string {
+func composeOrderBy(orderBy []map[string]string, orderByRaw *string, clauses []*qbClause, startedAt int) string {
 	if len(orderBy) > 0 {
 		orderVal := ""
+		i, k := startedAt, 0
 		for _, m := range orderBy {
 			for field, direct := range m {
+				if field == clauseColumn && k < len(clauses) {
+					field, i = renderPlaceholders(clauses[k].sql, i)
+					k++
+				}
 				if IsStringEmpty(orderVal) {
 					orderVal = " ORDER BY " + field + " " + direct
 				} else {
@@ -300,7 +466,7 @@ func buildColumnOptions(column *qbColumn) (colSchema string) {
 func composeIndex(tableName string, column *qbColumn) string {
 	if column.IsIndex {
 		return "CREATE INDEX " + applyIdxConcurrency(column.IsIdxConcurrent) + applyExistence(column.IfExists) +
-			column.IdxName + " ON " + tableName + " (" + column.Name + ")" + applyIncludes(column.Includes)
+			column.IdxName + " ON " + tableName + applyIdxMethod(column.IdxMethod) + " (" + column.Name + ")" + applyIncludes(column.Includes)
 	}
 	if column.IsUnique {
 		return "CREATE UNIQUE INDEX " + applyIdxConcurrency(column.IsIdxConcurrent) + applyExistence(column.IfExists) +
@@ -331,6 +497,13 @@ func applyIdxConcurrency(isIdxConcurrent bool) string {
 	return ""
 }
 
+func applyIdxMethod(method string) string {
+	if IsStringNotEmpty(method) {
+		return " USING " + method
+	}
+	return ""
+}
+
 func applyIncludes(includes []string) string {
 	if len(includes) > 0 {
 		incFields := ""
@@ -353,6 +526,50 @@ func composeComment(tableName string, column *qbColumn) string {
 	return ""
 }
 
+// quoteIdentifier validates (optionally schema/table qualified) identifier and quotes each of its parts
+func quoteIdentifier(identifier string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(identifier), ".")
+	for i, part := range parts {
+		if !identifierRegexp.MatchString(part) {
+			return "", fmt.Errorf("sql: invalid identifier %q", identifier)
+		}
+		parts[i] = `"` + part + `"`
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// composeColumnComparison builds "left" operator "right" for column-to-column conditions
+func composeColumnComparison(left, operator, right string) (string, error) {
+	operator = strings.ToUpper(strings.TrimSpace(operator))
+	if !columnComparisonOperators[operator] {
+		return "", fmt.Errorf("sql: invalid column comparison operator %q", operator)
+	}
+	l, err := quoteIdentifier(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := quoteIdentifier(right)
+	if err != nil {
+		return "", err
+	}
+	return l + " " + operator + " " + r, nil
+}
+
+// unqualifiedColumn strips table qualifier, ex.: users.id gives id as it is named in the result set
+func unqualifiedColumn(column string) string {
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		return strings.Trim(column[i+1:], `"`)
+	}
+	return strings.Trim(column, `"`)
+}
+
+// setErr keeps the first error of chained builder calls
+func (q *qbBuilder) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
 // SetCacheExecuteStmt to storage query SQL native executed
 func setCacheExecuteStmt(query string) {
 	cacheExecuteStmt = query
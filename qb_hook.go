@@ -0,0 +1,103 @@
+package qb
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Hook is called around every query run by QbDB/QbTxn including COPY of InsertBatch and BEGIN/COMMIT/ROLLBACK,
+// e.g. for logging, metrics or tracing
+type Hook interface {
+	// BeforeQuery is called before query runs, the returned context is passed to the driver and to AfterQuery
+	BeforeQuery(ctx context.Context, event QueryEvent) context.Context
+	// AfterQuery is called after query has run with its duration, rows affected and error
+	AfterQuery(ctx context.Context, event QueryEvent, err error)
+}
+
+// QueryEvent describes query passed to Hook
+type QueryEvent struct {
+	Operation     string        // the first keyword of SQL: SELECT, INSERT, UPDATE, DELETE, CREATE...
+	SQL           string        // SQL with $n placeholders
+	Args          []any         // values bound to placeholders, nil for COPY
+	ArgColumns    []string      // columns Args are compared to, set or inserted into by position, "" if unknown, nil if not tracked
+	Duration      time.Duration // set for AfterQuery
+	RowsAffected  int64         // set for AfterQuery: affected, returned or copied rows, -1 if unknown
+	InTransaction bool          // query runs in transaction
+	Attempt       int           // attempt of TransactionWithRetry, 1 for the first one and outside of it
+	start         time.Time
+}
+
+// AddHook adds hook called around every query run on the connection
+func (c *QbConn) AddHook(hook Hook) *QbConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook)
+	return c
+}
+
+// beforeQuery calls BeforeQuery of hooks returning context and event for afterQuery
+func (q *QbDB) beforeQuery(query string, args []any) (context.Context, QueryEvent) {
+	return q.beforeEvent(q.queryEvent(query, args))
+}
+
+// queryEvent creates event of query run by q
+func (q *QbDB) queryEvent(query string, args []any) QueryEvent {
+	event := QueryEvent{Operation: queryOperation(query), SQL: query, Args: args, Attempt: 1}
+	if len(q.argColumns) == len(args) {
+		event.ArgColumns = q.argColumns
+	}
+	if q.Txn != nil && q.Txn.Tx != nil {
+		event.InTransaction = true
+		event.Attempt = q.Txn.Attempt()
+	}
+	return event
+}
+
+// beforeEvent calls BeforeQuery of hooks with event returning context and event for afterQuery
+func (q *QbDB) beforeEvent(event QueryEvent) (context.Context, QueryEvent) {
+	event.start = time.Now()
+	ctx := context.Background()
+	for _, hook := range q.hooks() {
+		ctx = hook.BeforeQuery(ctx, event)
+	}
+	return ctx, event
+}
+
+// bound gets copy of q running the next query with args bound to columns, see QueryEvent.ArgColumns
+func (q *QbDB) bound(columns []string) *QbDB {
+	b := *q
+	b.argColumns = columns
+	return &b
+}
+
+// afterQuery calls AfterQuery of hooks
+func (q *QbDB) afterQuery(ctx context.Context, event QueryEvent, affected int64, err error) {
+	hooks := q.hooks()
+	if len(hooks) == 0 {
+		return
+	}
+	event.Duration = time.Since(event.start)
+	event.RowsAffected = affected
+	for _, hook := range hooks {
+		hook.AfterQuery(ctx, event, err)
+	}
+}
+
+func (q *QbDB) hooks() []Hook {
+	if q.Conn == nil {
+		return nil
+	}
+	q.Conn.mu.RLock()
+	defer q.Conn.mu.RUnlock()
+	return q.Conn.hooks
+}
+
+// queryOperation gets the first keyword of query
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,82 @@
+package qb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sivaosorg/qb/ll"
+)
+
+// LogHook logs queries with their args, duration and rows affected by ll logger:
+// errors with error level, queries slower than SlowThreshold with warn level and the rest with debug level, ex.:
+//
+//	conn.AddHook(&qb.LogHook{
+//		SlowThreshold: 200 * time.Millisecond,
+//		OnlySlow:      true,
+//		Redact:        []string{"password", "token"},
+//	})
+type LogHook struct {
+	Logger        *ll.Logger     // ll.D() by default
+	SlowThreshold time.Duration  // 0 disables slow query detection
+	OnlySlow      bool           // log slow queries and errors only
+	Redact        []string       // columns whose values are replaced by RedactedValue, matched against QueryEvent.ArgColumns
+	RedactValues  *regexp.Regexp // string values matching it are replaced by RedactedValue wherever they are bound, use it for raw SQL
+}
+
+// RedactedValue replaces redacted args in LogHook output
+const RedactedValue = "[REDACTED]"
+
+// BeforeQuery does nothing, queries are logged after they have run
+func (h *LogHook) BeforeQuery(ctx context.Context, _ QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery logs query
+func (h *LogHook) AfterQuery(_ context.Context, event QueryEvent, err error) {
+	slow := h.SlowThreshold > 0 && event.Duration >= h.SlowThreshold
+	if err == nil && !slow && h.OnlySlow {
+		return
+	}
+	logger := h.Logger
+	if logger == nil {
+		logger = ll.D()
+	}
+	message := fmt.Sprintf("sql=%s args=%v duration=%s rows=%d", event.SQL, h.redact(event), event.Duration, event.RowsAffected)
+	switch {
+	case err != nil:
+		logger.Error("%s error=%v", message, err)
+	case slow:
+		logger.Warn("slow query: %s", message)
+	default:
+		logger.Debug("%s", message)
+	}
+}
+
+// redact gets args of event with sensitive values replaced
+func (h *LogHook) redact(event QueryEvent) []any {
+	if len(h.Redact) == 0 && h.RedactValues == nil {
+		return event.Args
+	}
+	sensitive := make(map[string]bool, len(h.Redact))
+	for _, column := range h.Redact {
+		sensitive[strings.ToLower(column)] = true
+	}
+	args := make([]any, len(event.Args))
+	copy(args, event.Args)
+	for i, column := range event.ArgColumns {
+		if i < len(args) && sensitive[strings.ToLower(column)] {
+			args[i] = RedactedValue
+		}
+	}
+	if h.RedactValues != nil {
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && h.RedactValues.MatchString(s) {
+				args[i] = RedactedValue
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,313 @@
+package qb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// recordHook records events of AfterQuery
+type recordHook struct {
+	mu     sync.Mutex
+	events []QueryEvent
+	errs   []error
+}
+
+func (h *recordHook) BeforeQuery(ctx context.Context, _ QueryEvent) context.Context { return ctx }
+
+func (h *recordHook) AfterQuery(_ context.Context, event QueryEvent, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	h.errs = append(h.errs, err)
+}
+
+// operations gets Operation and Attempt of recorded events, ex.: COMMIT#2
+func (h *recordHook) operations() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	operations := make([]string, len(h.events))
+	for i, event := range h.events {
+		operations[i] = event.Operation + "#" + string(rune('0'+event.Attempt))
+	}
+	return operations
+}
+
+func TestHookRunsOnEveryPath(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(db *QbDB) error
+		want []string
+	}{
+		{
+			name: "commit",
+			run: func(db *QbDB) error {
+				return db.Transaction(func(tx *QbTxn) error {
+					_, err := tx.Table("t").Get()
+					return err
+				})
+			},
+			want: []string{"BEGIN#1", "SELECT#1", "COMMIT#1"},
+		},
+		{
+			name: "rollback",
+			run: func(db *QbDB) error {
+				_ = db.Transaction(func(tx *QbTxn) error { return errors.New("abort") })
+				return nil
+			},
+			want: []string{"BEGIN#1", "ROLLBACK#1"},
+		},
+		{
+			name: "savepoints",
+			run: func(db *QbDB) error {
+				return db.Transaction(func(tx *QbTxn) error {
+					_ = tx.Transaction(func(*QbTxn) error { return errors.New("abort") })
+					return tx.Transaction(func(*QbTxn) error { return nil })
+				})
+			},
+			want: []string{"BEGIN#1", "SAVEPOINT#1", "ROLLBACK#1", "SAVEPOINT#1", "RELEASE#1", "COMMIT#1"},
+		},
+		{
+			name: "copy of InsertBatch",
+			run: func(db *QbDB) error {
+				return db.Table("t").InsertBatch([]map[string]any{{"a": 1}, {"a": 2}})
+			},
+			want: []string{"BEGIN#1", "COPY#1", "COMMIT#1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := &recordHook{}
+			if err := tc.run(NewQbDb(newFakeDB().conn().AddHook(hook))); err != nil {
+				t.Fatal(err)
+			}
+			if got := hook.operations(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("events %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHookCopyEvent(t *testing.T) {
+	hook := &recordHook{}
+	db := NewQbDb(newFakeDB().conn().AddHook(hook))
+	if err := db.Table("t").InsertBatch([]map[string]any{{"a": 1}, {"a": 2}, {"a": 3}}); err != nil {
+		t.Fatal(err)
+	}
+	event := hook.events[1]
+	if event.SQL != `COPY "t" ("a") FROM STDIN` || event.Args != nil || event.RowsAffected != 3 || !event.InTransaction {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestHookSeesCommitFailureWithAttempt(t *testing.T) {
+	fake := newFakeDB()
+	commits := 0
+	fake.fail = func(query string) error {
+		if query == "COMMIT" {
+			commits++
+			if commits == 1 {
+				return &pq.Error{Code: "40001"}
+			}
+		}
+		return nil
+	}
+	hook := &recordHook{}
+	err := NewQbDb(fake.conn().AddHook(hook)).TransactionWithRetry(&RetryOptions{BaseDelay: 1}, func(tx *QbTxn) error {
+		_, err := tx.Table("t").Where("id", "=", 1).Update(map[string]any{"a": 1})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"BEGIN#1", "UPDATE#1", "COMMIT#1", "BEGIN#2", "UPDATE#2", "COMMIT#2"}
+	if got := hook.operations(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("events %v, want %v", got, want)
+	}
+	if !errors.Is(hook.errs[2], ErrSerialization) || hook.errs[5] != nil {
+		t.Errorf("commit errors %v, %v", hook.errs[2], hook.errs[5])
+	}
+}
+
+func TestHookArgColumns(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(db *QbDB) error
+		want []string
+	}{
+		{
+			name: "where",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Where("id", "=", 1).OrWhere(`"users"."Email"`, "=", "a").Get()
+				return err
+			},
+			want: []string{"id", "Email"},
+		},
+		{
+			name: "WhereIn and raw clause",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").WhereIn("id", []int{1, 2}).AndWhereYear("created_at", ">", 2024).Get()
+				return err
+			},
+			want: []string{"id", "id", ""},
+		},
+		{
+			name: "update",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Where("id", "=", 1).Update(map[string]any{"token": Expr("md5(? || ?)", "a", "b")})
+				return err
+			},
+			want: []string{"token", "token", "id"},
+		},
+		{
+			name: "DDL is not tracked",
+			run: func(db *QbDB) error {
+				_, err := db.Truncate("users")
+				return err
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := &recordHook{}
+			if err := tc.run(NewQbDb(newFakeDB().conn().AddHook(hook))); err != nil {
+				t.Fatal(err)
+			}
+			event := hook.events[len(hook.events)-1]
+			if !reflect.DeepEqual(event.ArgColumns, tc.want) {
+				t.Errorf("arg columns %q, want %q\nsql: %s", event.ArgColumns, tc.want, event.SQL)
+			}
+		})
+	}
+}
+
+func TestLogHookRedactsBuilderArgs(t *testing.T) {
+	const secret = "s3cret"
+	cases := []struct {
+		name string
+		run  func(db *QbDB) error
+	}{
+		{
+			name: "where",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Where("password", "=", secret).AndWhere("id", "=", 1).Get()
+				return err
+			},
+		},
+		{
+			name: "qualified and quoted column",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Where(`"users"."Password"`, "=", secret).Delete()
+				return err
+			},
+		},
+		{
+			name: "WhereIn",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").WhereIn("password", []string{secret, secret}).AndWhere("id", "=", 1).Get()
+				return err
+			},
+		},
+		{
+			name: "update with Expr value",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Where("id", "=", 1).
+					Update(map[string]any{"token": Expr("md5(? || salt)", secret), "name": "n"})
+				return err
+			},
+		},
+		{
+			name: "insert",
+			run: func(db *QbDB) error {
+				return db.Table("users").Insert(map[string]any{"name": "n", "password": secret})
+			},
+		},
+		{
+			name: "InsertMany",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").InsertMany([]map[string]any{{"name": "a", "password": secret}, {"name": "b"}})
+				return err
+			},
+		},
+		{
+			name: "UpdateBatch",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").UpdateBatch("id", []map[string]any{
+					{"id": 1, "token": secret},
+					{"id": 2, "token": Expr("upper(?)", secret)},
+				})
+				return err
+			},
+		},
+		{
+			name: "Upsert DoUpdateSet",
+			run: func(db *QbDB) error {
+				_, err := db.Table("users").Upsert().OnConflict("email").
+					DoUpdateSet(map[string]any{"token": Expr("md5(?)", secret), "name": "n"}).
+					Where("users.version < ?", 5).
+					Exec(map[string]any{"email": "e", "password": secret})
+				return err
+			},
+		},
+	}
+	h := &LogHook{Redact: []string{"password", "TOKEN"}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			fake.rows = func(query string) ([]string, [][]driver.Value) {
+				if strings.Contains(query, "pg_attribute") {
+					return []string{"attname", "format_type"}, [][]driver.Value{{"id", "integer"}, {"token", "text"}}
+				}
+				return nil, nil
+			}
+			hook := &recordHook{}
+			if err := tc.run(NewQbDb(fake.conn().AddHook(hook))); err != nil {
+				t.Fatal(err)
+			}
+			event := hook.events[len(hook.events)-1]
+			if event.Operation == "COMMIT" {
+				event = hook.events[len(hook.events)-2]
+			}
+			got := h.redact(event)
+			secrets := 0
+			for i, arg := range event.Args {
+				if arg == secret {
+					secrets++
+				}
+				if arg == secret && got[i] != RedactedValue || arg != secret && got[i] != arg {
+					t.Errorf("arg %d = %v redacted to %v, columns %q\nsql: %s", i, arg, got[i], event.ArgColumns, event.SQL)
+				}
+			}
+			if secrets == 0 {
+				t.Errorf("no secret args in %s", event.SQL)
+			}
+		})
+	}
+}
+
+func TestLogHookKeepsUnknownColumns(t *testing.T) {
+	h := &LogHook{Redact: []string{"password"}}
+	event := QueryEvent{
+		SQL:        `SELECT * FROM t WHERE lower(password) = lower($1) AND id = $2`,
+		Args:       []any{"a", "1"},
+		ArgColumns: []string{"", "id"},
+	}
+	if got := h.redact(event); !reflect.DeepEqual(got, []any{"a", "1"}) {
+		t.Errorf("args %v", got)
+	}
+}
+
+func TestLogHookRedactValues(t *testing.T) {
+	h := &LogHook{RedactValues: regexp.MustCompile(`^\d{13,19}$`)}
+	got := h.redact(QueryEvent{SQL: `SELECT * FROM t WHERE lower(card) = lower($1) AND id = $2`, Args: []any{"4111111111111111", "7"}})
+	if !reflect.DeepEqual(got, []any{RedactedValue, "7"}) {
+		t.Errorf("args %v", got)
+	}
+}
@@ -0,0 +1,72 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestIncrementDecrement(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(q *QbDB) (int64, error)
+		sql  []string // either order of SET columns
+		args []driver.Value
+	}{
+		{
+			name: "increment applies where clause",
+			run: func(q *QbDB) (int64, error) {
+				return q.Table("posts").Where("id", "=", 5).Increment("views", 1)
+			},
+			sql:  []string{`UPDATE "posts" SET views = views + $1 WHERE 1=1  AND id = $2`},
+			args: []driver.Value{1.0, "5"},
+		},
+		{
+			name: "decrement by fraction",
+			run: func(q *QbDB) (int64, error) {
+				return q.Table("accounts").Where("id", "=", 1).Decrement("balance", 2.5)
+			},
+			sql:  []string{`UPDATE "accounts" SET balance = balance - $1 WHERE 1=1  AND id = $2`},
+			args: []driver.Value{2.5, "1"},
+		},
+		{
+			name: "increase binds uint amount",
+			run: func(q *QbDB) (int64, error) {
+				return q.Table("posts").Where("id", "=", 5).Increase("views", 3)
+			},
+			sql:  []string{`UPDATE "posts" SET views = views + $1 WHERE 1=1  AND id = $2`},
+			args: []driver.Value{int64(3), "5"},
+		},
+		{
+			name: "extra columns in the same statement",
+			run: func(q *QbDB) (int64, error) {
+				return q.Table("posts").Where("id", "=", 5).Increment("views", 1, map[string]any{"viewed_at": Expr("NOW()")})
+			},
+			sql: []string{
+				`UPDATE "posts" SET views = views + $1, viewed_at = NOW() WHERE 1=1  AND id = $2`,
+				`UPDATE "posts" SET viewed_at = NOW(), views = views + $1 WHERE 1=1  AND id = $2`,
+			},
+			args: []driver.Value{1.0, "5"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			affected, err := tc.run(NewQbDb(fake.conn()))
+			if err != nil || affected != 1 {
+				t.Fatalf("affected = %d, err = %v", affected, err)
+			}
+			got := fake.queries[0]
+			matched := false
+			for _, sql := range tc.sql {
+				matched = matched || got.sql == sql
+			}
+			if !matched {
+				t.Errorf("sql:\n got: %s\nwant: %s", got.sql, tc.sql[0])
+			}
+			if !reflect.DeepEqual(got.args, tc.args) {
+				t.Errorf("args = %#v, want %#v", got.args, tc.args)
+			}
+		})
+	}
+}
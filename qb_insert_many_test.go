@@ -0,0 +1,188 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChunkRows(t *testing.T) {
+	columns := []string{"a", "b"}
+	row := map[string]any{"a": 1, "b": 2}
+	half := map[string]any{"a": 1}
+	expr := map[string]any{"a": Expr("? + ? + ?", 1, 2, 3), "b": Expr("NOW()")}
+	tests := []struct {
+		name  string
+		rows  []map[string]any
+		limit int
+		sizes []int
+	}{
+		{name: "fits", rows: []map[string]any{row, row}, limit: 4, sizes: []int{2}},
+		{name: "split at limit", rows: []map[string]any{row, row, row}, limit: 4, sizes: []int{2, 1}},
+		{name: "missing keys take no bindings", rows: []map[string]any{half, half, half, half, row}, limit: 4, sizes: []int{4, 1}},
+		{name: "expression args are counted", rows: []map[string]any{expr, row, expr}, limit: 5, sizes: []int{2, 1}},
+		{name: "row over limit goes alone", rows: []map[string]any{row, row}, limit: 1, sizes: []int{1, 1}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := chunkRows(columns, tc.rows, tc.limit)
+			sizes := make([]int, len(chunks))
+			for i, chunk := range chunks {
+				sizes[i] = len(chunk)
+			}
+			if !reflect.DeepEqual(sizes, tc.sizes) {
+				t.Errorf("chunk sizes = %v, want %v", sizes, tc.sizes)
+			}
+		})
+	}
+}
+
+func TestComposeInsertMany(t *testing.T) {
+	rows := []map[string]any{
+		{"email": "a@x.io", "role": "admin"},
+		{"email": "b@x.io"},
+		{"name": "c", "tags": []string{"x"}},
+	}
+	q := newTestDB().Table("users")
+	columns := unionColumns(rows)
+	if want := []string{"email", "name", "role", "tags"}; !reflect.DeepEqual(columns, want) {
+		t.Fatalf("columns = %v, want %v", columns, want)
+	}
+	query, args, _ := q.Builder.composeInsertMany(columns, rows)
+	want := `INSERT INTO "users" (email, name, role, tags) VALUES ($1, DEFAULT, $2, DEFAULT), ($3, DEFAULT, DEFAULT, DEFAULT), (DEFAULT, $4, DEFAULT, $5)`
+	if query != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", query, want)
+	}
+	assertArgs(t, args, []any{"a@x.io", "admin", "b@x.io", "c", prepareArg([]string{"x"})})
+
+	q.OnConflictRaw("(email) DO NOTHING")
+	if query, _, _ = q.Builder.composeInsertMany(columns, rows[:1]); !strings.HasSuffix(query, " ON CONFLICT (email) DO NOTHING") {
+		t.Errorf("on conflict is missing: %s", query)
+	}
+}
+
+func TestPrepareInsertBatchAlignment(t *testing.T) {
+	columns, values := prepareInsertBatch([]map[string]any{
+		{"b": 2, "a": 1},
+		{"c": 3},
+		{"a": 4, "c": []int{5}},
+	})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(columns, want) {
+		t.Fatalf("columns = %v, want %v", columns, want)
+	}
+	want := [][]any{{1, 2, nil}, {nil, nil, 3}, {4, nil, prepareArg([]int{5})}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %#v, want %#v", values, want)
+	}
+}
+
+func TestInsertManySplitsUnderMaxBindings(t *testing.T) {
+	fake := newFakeDB()
+	rows := make([]map[string]any, MaxBindings/2+10)
+	for i := range rows {
+		rows[i] = map[string]any{"a": i, "b": "x"}
+	}
+	n, err := NewQbDb(fake.conn()).Table("t").InsertMany(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 { // the test driver reports one affected row per stmt
+		t.Errorf("affected = %d", n)
+	}
+	statements := fake.statements()
+	if len(statements) != 4 || statements[0] != "BEGIN" || statements[3] != "COMMIT" {
+		t.Fatalf("statements = %d, first %q, last %q", len(statements), statements[0], statements[len(statements)-1])
+	}
+	total := 0
+	for _, query := range fake.queries[1:3] {
+		if len(query.args) > MaxBindings {
+			t.Errorf("stmt has %d bindings over %d", len(query.args), MaxBindings)
+		}
+		total += len(query.args)
+	}
+	if total != 2*len(rows) {
+		t.Errorf("bindings = %d, want %d", total, 2*len(rows))
+	}
+}
+
+func TestOnConflictRawRejected(t *testing.T) {
+	fake := newFakeDB()
+	db := NewQbDb(fake.conn())
+	data := map[string]any{"email": "a@x.io"}
+	if _, err := db.Table("users").OnConflictRaw("(email) DO NOTHING").Replace(data, "email"); err == nil || !strings.Contains(err.Error(), "OnConflictRaw") {
+		t.Errorf("Replace err = %v", err)
+	}
+	if _, err := db.Table("users").OnConflictRaw("(email) DO NOTHING").Returning("id").Replace(data, "email"); err == nil {
+		t.Error("expected Returning.Replace error")
+	}
+	if err := db.Table("users").OnConflictRaw("(email) DO NOTHING").InsertBatch([]map[string]any{data}); err == nil {
+		t.Error("expected InsertBatch error")
+	}
+	if len(fake.queries) != 0 {
+		t.Errorf("statements were executed: %v", fake.statements())
+	}
+	fake.rows = func(string) ([]string, [][]driver.Value) { return nil, nil }
+	if err := db.Table("users").OnConflictRaw("(email) DO NOTHING").Insert(data); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fake.statements()[0], `INSERT INTO "users" (email) VALUES($1) ON CONFLICT (email) DO NOTHING`; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestInsertManyStartsTransactionForSeveralStmts(t *testing.T) {
+	many := make([]map[string]any, MaxBindings+1)
+	for i := range many {
+		many[i] = map[string]any{"a": i}
+	}
+	tests := []struct {
+		name string
+		run  func(q *QbDB, rows []map[string]any) error
+		rows []map[string]any
+		want []string
+	}{
+		{
+			name: "InsertMany one stmt",
+			run:  func(q *QbDB, rows []map[string]any) error { _, err := q.InsertMany(rows); return err },
+			rows: []map[string]any{{"a": 1}, {"a": 2}},
+			want: []string{"INSERT"},
+		},
+		{
+			name: "InsertMany several stmts",
+			run:  func(q *QbDB, rows []map[string]any) error { _, err := q.InsertMany(rows); return err },
+			rows: many,
+			want: []string{"BEGIN", "INSERT", "INSERT", "COMMIT"},
+		},
+		{
+			name: "Returning InsertMany one stmt",
+			run:  func(q *QbDB, rows []map[string]any) error { _, err := q.Returning().InsertMany(rows); return err },
+			rows: []map[string]any{{"a": 1}},
+			want: []string{"INSERT"},
+		},
+		{
+			name: "Returning InsertBatch several stmts",
+			run:  func(q *QbDB, rows []map[string]any) error { _, err := q.Returning().InsertBatch(rows); return err },
+			rows: many,
+			want: []string{"BEGIN", "INSERT", "INSERT", "COMMIT"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			if err := tc.run(NewQbDb(fake.conn()).Table("t"), tc.rows); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, query := range fake.queries {
+				got = append(got, queryOperation(query.sql))
+				if len(query.args) > MaxBindings {
+					t.Errorf("stmt has %d bindings over %d", len(query.args), MaxBindings)
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("statements %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
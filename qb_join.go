@@ -69,6 +69,7 @@ func (q *QbDB) FullOuterJoinIf(fnc func() bool, table, left, operator, right str
 
 func (q *QbDB) buildJoin(joinType, table, on string) *QbDB {
 	q.Builder.join = append(q.Builder.join, fmt.Sprintf("%s%s%s%s%s%s%s", " ", joinType, " JOIN ", table, " ON ", on, " "))
+	q.Builder.joins = append(q.Builder.joins, qbJoin{kind: joinType, table: table, on: on})
 	return q
 }
 
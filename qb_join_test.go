@@ -0,0 +1,86 @@
+package qb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinsInUpdateAndDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(q *QbDB) *QbDB
+		update  bool
+		sql     string
+		args    []any
+		wantErr string
+	}{
+		{
+			name: "delete translates inner joins into USING",
+			build: func(q *QbDB) *QbDB {
+				return q.Table("orders").InnerJoin("users", "users.id", "=", "orders.user_id").
+					InnerJoin("shops", "shops.id", "=", "orders.shop_id").
+					Where("users.banned", "=", true).OrWhere("shops.closed", "=", true)
+			},
+			sql: `DELETE FROM "orders" USING users, shops WHERE (1=1  AND users.banned = $1 OR shops.closed = $2)` +
+				` AND users.id=orders.user_id AND shops.id=orders.shop_id`,
+			args: []any{prepareArg(true), prepareArg(true)},
+		},
+		{
+			name: "delete with joins and no where",
+			build: func(q *QbDB) *QbDB {
+				return q.Table("orders").InnerJoin("users", "users.id", "=", "orders.user_id")
+			},
+			sql: `DELETE FROM "orders" USING users WHERE users.id=orders.user_id`,
+		},
+		{
+			name: "update translates inner joins into FROM, where bindings follow SET",
+			build: func(q *QbDB) *QbDB {
+				return q.Table("orders").InnerJoin("users", "users.id", "=", "orders.user_id").Where("users.vip", "=", "yes")
+			},
+			update: true,
+			sql:    `UPDATE "orders" SET discount = $1 FROM users WHERE (1=1  AND users.vip = $2) AND users.id=orders.user_id`,
+			args:   []any{"10", "yes"},
+		},
+		{
+			name: "joins following From table are kept",
+			build: func(q *QbDB) *QbDB {
+				return q.Table("orders").From("users").LeftJoin("shops", "shops.id", "=", "users.shop_id").
+					Where("orders.user_id", "=", Expr("users.id"))
+			},
+			sql: `DELETE FROM "orders" USING users LEFT JOIN shops ON shops.id=users.shop_id WHERE 1=1  AND orders.user_id = users.id`,
+		},
+		{
+			name: "outer join without From table",
+			build: func(q *QbDB) *QbDB {
+				return q.Table("orders").LeftJoin("users", "users.id", "=", "orders.user_id")
+			},
+			wantErr: "LEFT JOIN can't be used",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.build(newTestDB())
+			var query string
+			var args []any
+			var err error
+			if tc.update {
+				query, args, _, err = q.Builder.composeUpdate(map[string]any{"discount": 10})
+			} else {
+				query, args, _, err = q.Builder.composeDelete()
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", query, tc.sql)
+			}
+			assertArgs(t, args, tc.args)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetPrimaryKey sets key columns of table used by InsertGetId, InsertGetKey, Find and FindBy,
+// tables without key set use DefaultKeyColumn
+func (c *QbConn) SetPrimaryKey(table string, columns ...string) *QbConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.keys == nil {
+		c.keys = make(map[string][]string)
+	}
+	c.keys[table] = columns
+	return c
+}
+
+// PrimaryKey gets key columns set for table, nil if there were no SetPrimaryKey call for it
+func (c *QbConn) PrimaryKey(table string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys[table]
+}
+
+// Key sets key columns for the current call only, overriding the ones set by SetPrimaryKey
+func (q *QbDB) Key(columns ...string) *QbDB {
+	q.Builder.keyColumns = columns
+	return q
+}
+
+// InsertGetKey inserts one row with param bindings returning its key scanned into T, ex.:
+//
+//	id, err := qb.InsertGetKey[string](db.Table("users").Key("user_id"), data) // uuid key
+func InsertGetKey[T any](q *QbDB, data map[string]any) (T, error) {
+	var key T
+	if len(data) == 0 {
+		return key, ErrEmptyData
+	}
+	builder := q.Builder
+	if IsStringEmpty(builder.table) {
+		return key, ErrNoTable
+	}
+	keys := builder.primaryKey(q.Conn)
+	if len(keys) != 1 {
+		return key, errCompositeKey(builder.table, keys)
+	}
+	query, values, columns := builder.composeInsert(data)
+	query += " RETURNING " + keys[0]
+	err := q.bound(columns).queryRow(query, values...).Scan(&key)
+	return key, err
+}
+
+// InsertGetKeys inserts one row with param bindings returning its (composite) key as column-value map
+func (q *QbDB) InsertGetKeys(data map[string]any) (map[string]any, error) {
+	rows, err := q.Returning(q.Builder.primaryKey(q.Conn)...).Insert(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no records were produced by query: %s", ErrNotFound, q.GetRawSQL())
+	}
+	return rows[0], nil
+}
+
+// FindBy retrieves a single row by its key column value of any type (e.g. uuid string)
+func FindBy[T any](q *QbDB, key T) (map[string]any, error) {
+	keys := q.Builder.primaryKey(q.Conn)
+	if len(keys) != 1 {
+		return nil, errCompositeKey(q.Builder.table, keys)
+	}
+	return q.Where(keys[0], "=", key).First()
+}
+
+// FindByKeys retrieves a single row by its composite key passed as column-value map
+func (q *QbDB) FindByKeys(keys map[string]any) (map[string]any, error) {
+	if len(keys) == 0 {
+		return nil, ErrEmptyData
+	}
+	for column, value := range keys {
+		q.AndWhere(column, "=", value)
+	}
+	return q.First()
+}
+
+// primaryKey resolves key columns: the current call ones, then the table ones set on conn, then DefaultKeyColumn,
+// QbTxn created as literal has no conn to look table keys up, so it gets DefaultKeyColumn unless Key is set
+func (q *qbBuilder) primaryKey(conn *QbConn) []string {
+	if len(q.keyColumns) > 0 {
+		return q.keyColumns
+	}
+	if conn != nil {
+		if keys := conn.PrimaryKey(q.table); len(keys) > 0 {
+			return keys
+		}
+	}
+	return []string{DefaultKeyColumn}
+}
+
+func errCompositeKey(table string, keys []string) error {
+	return fmt.Errorf("sql: table %q has composite key (%s), use InsertGetKeys/FindByKeys", table, strings.Join(keys, ", "))
+}
@@ -0,0 +1,95 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPrimaryKeyResolution(t *testing.T) {
+	conn := newFakeDB().conn()
+	conn.SetPrimaryKey("accounts", "account_id")
+	conn.SetPrimaryKey("memberships", "user_id", "group_id")
+	tests := []struct {
+		name  string
+		conn  *QbConn
+		table string
+		key   []string
+		want  []string
+	}{
+		{name: "default", conn: conn, table: "users", want: []string{DefaultKeyColumn}},
+		{name: "table key", conn: conn, table: "accounts", want: []string{"account_id"}},
+		{name: "composite table key", conn: conn, table: "memberships", want: []string{"user_id", "group_id"}},
+		{name: "call key wins over table key", conn: conn, table: "accounts", key: []string{"uid"}, want: []string{"uid"}},
+		{name: "call key without conn", table: "accounts", key: []string{"uid"}, want: []string{"uid"}},
+		{name: "no conn and no call key", table: "accounts", want: []string{DefaultKeyColumn}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewQbDb(tc.conn).Table(tc.table)
+			if tc.key != nil {
+				q.Key(tc.key...)
+			}
+			if got := q.Builder.primaryKey(q.Conn); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInsertGetKey(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(query string) ([]string, [][]driver.Value) {
+		return []string{"account_id"}, [][]driver.Value{{"6f1c"}}
+	}
+	db := NewQbDb(fake.conn())
+	db.Conn.SetPrimaryKey("accounts", "account_id")
+	db.Conn.SetPrimaryKey("memberships", "user_id", "group_id")
+
+	id, err := InsertGetKey[string](db.Table("accounts"), map[string]any{"name": "a"})
+	if err != nil || id != "6f1c" {
+		t.Fatalf("id = %q, err = %v", id, err)
+	}
+	if got, want := fake.statements()[0], `INSERT INTO "accounts" (name) VALUES($1) RETURNING account_id`; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+	if _, err := InsertGetKey[string](db.Table("memberships"), map[string]any{"user_id": 1}); err == nil || !strings.Contains(err.Error(), "composite key") {
+		t.Errorf("composite key err = %v", err)
+	}
+	if _, err := FindBy(db.Table("memberships"), 1); err == nil || !strings.Contains(err.Error(), "composite key") {
+		t.Errorf("composite key FindBy err = %v", err)
+	}
+}
+
+func TestTxnLiteralKey(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"id"}, [][]driver.Value{{int64(9)}}
+	}
+	db := NewQbDb(fake.conn())
+	tx, err := db.Sql().Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	txn := &QbTxn{Tx: tx, Builder: newBuilder()}
+	txn.Builder.table = "accounts"
+	if id, err := txn.InsertGetId(map[string]any{"name": "a"}); err != nil || id != 9 {
+		t.Fatalf("id = %d, err = %v", id, err)
+	}
+	statements := fake.statements()
+	if got, want := statements[len(statements)-1], `INSERT INTO "accounts" (name) VALUES($1) RETURNING id`; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+	txn.Builder.keyColumns = []string{"account_id"}
+	id, err := txn.InsertGetId(map[string]any{"name": "a"})
+	if err != nil || id != 9 {
+		t.Fatalf("id = %d, err = %v", id, err)
+	}
+	statements = fake.statements()
+	if got, want := statements[len(statements)-1], `INSERT INTO "accounts" (name) VALUES($1) RETURNING account_id`; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+}
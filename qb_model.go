@@ -1,22 +1,39 @@
 package qb
 
-import "database/sql"
+import (
+	"database/sql"
+	"sync"
+)
 
 type qbColType string
 
 type QbConn struct {
-	db *sql.DB `json:"-"`
+	db      *sql.DB             `json:"-"`
+	keys    map[string][]string `json:"-"` // key columns per table, see SetPrimaryKey
+	mu      sync.RWMutex        `json:"-"`
+	err     error               // error of opening db, see NewQbConn
+	cluster *qbCluster          // read replicas, see NewQbCluster
+	hooks   []Hook              // see AddHook
 }
 
 type QbDB struct {
-	Builder *qbBuilder `json:"-"`
-	Conn    *QbConn    `json:"-"`
-	Txn     *QbTxn     `json:"-"`
+	Builder    *qbBuilder `json:"-"`
+	Conn       *QbConn    `json:"-"`
+	Txn        *QbTxn     `json:"-"`
+	executor   Executor   // set by UseExecutor
+	argColumns []string   // columns args of query are bound to, see bound
 }
 
+// QbTxn is the transaction started by Begin/Transaction, it has QbDB methods running in it
 type QbTxn struct {
-	Tx      *sql.Tx    `json:"-"`
-	Builder *qbBuilder `json:"-"`
+	*QbDB      `json:"-"`
+	Tx         *sql.Tx    `json:"-"`
+	Builder    *qbBuilder `json:"-"`
+	parent     *QbTxn     // the enclosing transaction of savepoint
+	savepoint  string     // savepoint name of nested transaction
+	savepoints int        // number of savepoints started in the outermost transaction
+	attempt    int        // attempt of TransactionWithRetry
+	done       bool
 }
 
 // QbTable is the type for operations on table schema
@@ -38,10 +55,14 @@ type qbBuilder struct {
 	table           string
 	from            string
 	join            []string
+	joins           []qbJoin // structured join, so UPDATE/DELETE can translate them into FROM/USING
 	orderBy         []map[string]string
+	orderClauses    []*qbClause // bound order by expressions, rendered in place of clauseColumn entries
+	selectClauses   []*qbClause // bound select expressions, rendered in place of clauseColumn entries
 	orderByRaw      *string
-	groupBy         string
+	groupBy         []string
 	having          string
+	havingBindings  []map[string]any
 	columns         []string
 	union           []string
 	isUnionAll      bool
@@ -51,6 +72,25 @@ type qbBuilder struct {
 	size            int64 // support pagination
 	lockForUpdate   *string
 	whereExists     string
+	keyColumns      []string // key columns of the current call, see Key
+	onConflict      string   // ON CONFLICT clause of inserts, see OnConflictRaw
+	usePrimary      bool     // reads of the current call run on primary, see UsePrimary
+	err             error    // the first invalid input of chained calls, returned on execution
+}
+
+// qbClause is a raw where condition whose ? placeholders are bound to args in order
+type qbClause struct {
+	sql  string
+	args []any
+}
+
+func (c *qbClause) clause() *qbClause {
+	return c
+}
+
+// qbExpression is implemented by values rendered inline as sql with their own bindings
+type qbExpression interface {
+	clause() *qbClause
 }
 
 type qbColumn struct {
@@ -69,7 +109,15 @@ type qbColumn struct {
 	Default         *string
 	ForeignKey      *string
 	IdxName         string
+	IdxMethod       string
 	Comment         *string
 	Collation       *string
 	Operator        string
 }
+
+// qbJoin is the join added by InnerJoin/LeftJoin/RightJoin/FullJoin
+type qbJoin struct {
+	kind  string
+	table string
+	on    string
+}
@@ -2,10 +2,7 @@ package qb
 
 import (
 	"fmt"
-	"log"
 	"strings"
-
-	"github.com/lib/pq"
 )
 
 func NewQbOps() *QbOps {
@@ -19,6 +16,7 @@ func (q *QbOps) SetArgs(value map[string]interface{}) *QbOps {
 	return q
 }
 
+// SetField sets field value when fnc returns true, *QbExpr values are rendered inline by Insert/Update/Replace
 func (q *QbOps) SetField(fnc func() bool, field string, value interface{}) *QbOps {
 	if q.args == nil {
 		q.SetArgs(map[string]interface{}{})
@@ -48,19 +46,17 @@ func (q *QbOps) Json() string {
 // Insert inserts one row with param bindings
 func (q *QbDB) Insert(data map[string]any) error {
 	if len(data) == 0 {
-		return fmt.Errorf("Data is empty")
+		return ErrEmptyData
 	}
 	if q.Txn != nil {
 		return q.Txn.Insert(data)
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return errTableCallBeforeOp
+		return ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `)`
-	setCacheExecuteStmt(query)
-	_, err := q.Sql().Exec(query, values...)
+	query, values, columns := builder.composeInsert(data)
+	_, err := q.bound(columns).exec(query, values...)
 	if err != nil {
 		return err
 	}
@@ -75,19 +71,17 @@ func (q *QbDB) InsertIf(ops *QbOps) error {
 // Insert inserts one row with param bindings
 func (q *QbTxn) Insert(data map[string]any) error {
 	if len(data) == 0 {
-		return fmt.Errorf("Data is empty")
+		return ErrEmptyData
 	}
 	if q.Tx == nil {
 		return errTransactionModeWithoutTx
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return errTableCallBeforeOp
+		return ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `)`
-	setCacheExecuteStmt(query)
-	_, err := q.Tx.Exec(query, values...)
+	query, values, columns := builder.composeInsert(data)
+	_, err := q.db().bound(columns).exec(query, values...)
 	if err != nil {
 		return err
 	}
@@ -99,27 +93,10 @@ func (q *QbTxn) InsertIf(ops *QbOps) error {
 	return q.Insert(ops.GetArgs())
 }
 
-// InsertGetId inserts one row with param bindings and returning id
+// InsertGetId inserts one row with param bindings and returning id,
+// the key column is resolved by Key/SetPrimaryKey and defaults to id
 func (q *QbDB) InsertGetId(data map[string]any) (uint64, error) {
-	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
-	}
-	if q.Txn != nil {
-		return q.Txn.InsertGetId(data)
-	}
-	builder := q.Builder
-	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
-	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `) RETURNING id`
-	setCacheExecuteStmt(query)
-	var id uint64
-	err := q.Sql().QueryRow(query, values...).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-	return id, nil
+	return InsertGetKey[uint64](q, data)
 }
 
 // InsertGetIdIf inserts one row with param bindings and returning id
@@ -129,25 +106,10 @@ func (q *QbDB) InsertGetIdIf(ops *QbOps) (uint64, error) {
 
 // InsertGetId inserts one row with param bindings and returning id
 func (q *QbTxn) InsertGetId(data map[string]any) (uint64, error) {
-	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
-	}
 	if q.Tx == nil {
 		return 0, errTransactionModeWithoutTx
 	}
-	builder := q.Builder
-	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
-	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `) RETURNING id`
-	setCacheExecuteStmt(query)
-	var id uint64
-	err := q.Tx.QueryRow(query, values...).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-	return id, nil
+	return InsertGetKey[uint64](q.db(), data)
 }
 
 // InsertGetIdIf inserts one row with param bindings and returning id
@@ -155,43 +117,24 @@ func (q *QbTxn) InsertGetIdIf(ops *QbOps) (uint64, error) {
 	return q.InsertGetId(ops.GetArgs())
 }
 
-// InsertBatch inserts multiple rows based on transaction
+// InsertBatch inserts multiple rows with COPY FROM, runs in transaction if it is active or in its own one,
+// columns are the union of all rows keys, missing values are copied as NULL (COPY has no DEFAULT),
+// use InsertMany for DEFAULT, Expr values, ON CONFLICT and RETURNING
 func (q *QbDB) InsertBatch(data []map[string]any) error {
 	if len(data) == 0 {
-		return fmt.Errorf("Data is empty")
+		return ErrEmptyData
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return errTableCallBeforeOp
+		return ErrNoTable
 	}
-	txn, err := q.Sql().Begin()
-	if err != nil {
-		log.Fatal(err)
+	if IsStringNotEmpty(builder.onConflict) {
+		return errOnConflictRaw("InsertBatch")
 	}
 	columns, values := prepareInsertBatch(data)
-	stmt, err := txn.Prepare(pq.CopyIn(builder.table, columns...))
-	if err != nil {
-		return err
-	}
-	for _, value := range values {
-		_, err = stmt.Exec(value...)
-		if err != nil {
-			return err
-		}
-	}
-	_, err = stmt.Exec()
-	if err != nil {
-		return err
-	}
-	err = stmt.Close()
-	if err != nil {
-		return err
-	}
-	err = txn.Commit()
-	if err != nil {
-		return err
-	}
-	return nil
+	return q.withTxn(func() error {
+		return q.copyIn(builder.table, columns, values)
+	})
 }
 
 // InsertBatchIf inserts multiple rows based on transaction
@@ -203,37 +146,80 @@ func (q *QbDB) InsertBatchIf(ops ...*QbOps) error {
 	return q.InsertBatch(data)
 }
 
+// OnConflictRaw sets ON CONFLICT clause for Insert/InsertMany, Replace and InsertBatch reject it, ex.:
+//
+//	db.Table("users").OnConflictRaw("(email) DO NOTHING").InsertMany(rows)
+func (q *QbDB) OnConflictRaw(clause string) *QbDB {
+	q.Builder.onConflict = clause
+	return q
+}
+
+// InsertMany inserts multiple rows with INSERT ... VALUES (...), (...) stmts returning the number of inserted rows,
+// columns are the union of all rows keys, missing values are filled with DEFAULT,
+// rows are split into several stmts under MaxBindings, that run in transaction if it is active or in its own one,
+// rows fitting in one stmt are inserted without starting transaction
+func (q *QbDB) InsertMany(rows []map[string]any) (int64, error) {
+	if len(rows) == 0 {
+		return 0, ErrEmptyData
+	}
+	builder := q.Builder
+	if IsStringEmpty(builder.table) {
+		return 0, ErrNoTable
+	}
+	columns := unionColumns(rows)
+	chunks := chunkRows(columns, rows, MaxBindings)
+	var affected int64
+	err := q.withTxnFor(len(chunks), func() error {
+		for _, chunk := range chunks {
+			query, values, argColumns := builder.composeInsertMany(columns, chunk)
+			res, err := q.bound(argColumns).exec(query, values...)
+			if err != nil {
+				return err
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			affected += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// InsertManyIf inserts multiple rows with INSERT ... VALUES (...), (...) stmts returning the number of inserted rows
+func (q *QbDB) InsertManyIf(ops ...*QbOps) (int64, error) {
+	data := []map[string]any{}
+	for _, v := range ops {
+		data = append(data, v.GetArgs())
+	}
+	return q.InsertMany(data)
+}
+
 // Update builds an UPDATE sql stmt with corresponding where/from clauses if stated
 // returning affected rows
 func (q *QbDB) Update(data map[string]any) (int64, error) {
 	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
+		return 0, ErrEmptyData
 	}
 	if q.Txn != nil {
 		return q.Txn.Update(data)
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	setVal := ""
-	l := len(columns)
-	for k, col := range columns {
-		setVal += fmt.Sprintf("%s%s%s", col, " = ", bindings[k])
-		if k < l-1 {
-			setVal += ", "
-		}
+	if builder.err != nil {
+		return 0, builder.err
 	}
-	query := `UPDATE "` + q.Builder.table + `" SET ` + setVal
-	if IsStringNotEmpty(q.Builder.from) {
-		query += fmt.Sprintf("%s%s", " FROM ", q.Builder.from)
+	query, values, columns, err := builder.composeUpdate(data)
+	if err != nil {
+		return 0, err
 	}
-	q.Builder.startBindingsAt = l + 1
-	query += q.Builder.buildClauses()
-	values = append(values, prepareValues(q.Builder.whereBindings)...)
-	setCacheExecuteStmt(query)
-	result, err := q.Sql().Exec(query, values...)
+	result, err := q.bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
@@ -250,33 +236,23 @@ func (q *QbDB) UpdateIf(ops *QbOps) (int64, error) {
 // returning affected rows
 func (q *QbTxn) Update(data map[string]any) (int64, error) {
 	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
+		return 0, ErrEmptyData
 	}
 	if q.Tx == nil {
 		return 0, errTransactionModeWithoutTx
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	setVal := ""
-	l := len(columns)
-	for k, col := range columns {
-		setVal += fmt.Sprintf("%s%s%s", col, " = ", bindings[k])
-		if k < l-1 {
-			setVal += ", "
-		}
+	if builder.err != nil {
+		return 0, builder.err
 	}
-	query := `UPDATE "` + q.Builder.table + `" SET ` + setVal
-	if IsStringNotEmpty(q.Builder.from) {
-		query += fmt.Sprintf("%s%s", " FROM ", q.Builder.from)
+	query, values, columns, err := builder.composeUpdate(data)
+	if err != nil {
+		return 0, err
 	}
-	q.Builder.startBindingsAt = l + 1
-	query += q.Builder.buildClauses()
-	values = append(values, prepareValues(q.Builder.whereBindings)...)
-	setCacheExecuteStmt(query)
-	result, err := q.Tx.Exec(query, values...)
+	result, err := q.db().bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
@@ -289,27 +265,24 @@ func (q *QbTxn) UpdateIf(ops *QbOps) (int64, error) {
 	return q.Update(ops.GetArgs())
 }
 
-// Replace inserts data if conflicting row hasn't been found, else it will update an existing one
+// Replace inserts data if conflicting row hasn't been found, else it will update an existing one,
+// data holding conflict columns only leaves the conflicting row as is and gives 0 affected rows
 func (q *QbDB) Replace(data map[string]any, conflict string) (int64, error) {
 	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
+		return 0, ErrEmptyData
 	}
 	if q.Txn != nil {
 		return q.Txn.Replace(data, conflict)
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `) ON CONFLICT(` + conflict + `) DO UPDATE SET `
-	for i, v := range columns {
-		// columns[i] = v + " = excluded." + v
-		columns[i] = fmt.Sprintf("%s%s%s", v, " = excluded.", v)
+	query, values, columns, err := builder.composeReplace(data, conflict)
+	if err != nil {
+		return 0, err
 	}
-	query += strings.Join(columns, ", ")
-	setCacheExecuteStmt(query)
-	result, err := q.Sql().Exec(query, values...)
+	result, err := q.bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
@@ -324,24 +297,20 @@ func (q *QbDB) ReplaceIf(ops *QbOps, conflict string) (int64, error) {
 // Replace inserts data if conflicting row hasn't been found, else it will update an existing one
 func (q *QbTxn) Replace(data map[string]any, conflict string) (int64, error) {
 	if len(data) == 0 {
-		return 0, fmt.Errorf("Data is empty")
+		return 0, ErrEmptyData
 	}
 	if q.Tx == nil {
 		return 0, errTransactionModeWithoutTx
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
 	}
-	columns, values, bindings := prepareBindings(data)
-	query := `INSERT INTO "` + builder.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `) ON CONFLICT(` + conflict + `) DO UPDATE SET `
-	for i, v := range columns {
-		// columns[i] = v + " = excluded." + v
-		columns[i] = fmt.Sprintf("%s%s%s", v, " = excluded.", v)
+	query, values, columns, err := builder.composeReplace(data, conflict)
+	if err != nil {
+		return 0, err
 	}
-	query += strings.Join(columns, ", ")
-	setCacheExecuteStmt(query)
-	result, err := q.Tx.Exec(query, values...)
+	result, err := q.db().bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
@@ -360,12 +329,16 @@ func (q *QbDB) Delete() (int64, error) {
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
+	}
+	if builder.err != nil {
+		return 0, builder.err
+	}
+	query, values, columns, err := builder.composeDelete()
+	if err != nil {
+		return 0, err
 	}
-	query := `DELETE FROM "` + q.Builder.table + `"`
-	query += q.Builder.buildClauses()
-	setCacheExecuteStmt(query)
-	result, err := q.Sql().Exec(query, prepareValues(q.Builder.whereBindings)...)
+	result, err := q.bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
@@ -380,27 +353,68 @@ func (q *QbTxn) Delete() (int64, error) {
 	}
 	builder := q.Builder
 	if IsStringEmpty(builder.table) {
-		return 0, errTableCallBeforeOp
+		return 0, ErrNoTable
+	}
+	if builder.err != nil {
+		return 0, builder.err
 	}
-	query := `DELETE FROM "` + q.Builder.table + `"`
-	query += q.Builder.buildClauses()
-	setCacheExecuteStmt(query)
-	result, err := q.Tx.Exec(query, prepareValues(q.Builder.whereBindings)...)
+	query, values, columns, err := builder.composeDelete()
+	if err != nil {
+		return 0, err
+	}
+	result, err := q.db().bound(columns).exec(query, values...)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
+// withTxnFor runs fn executing stmts number of stmts like withTxn, a single stmt is atomic on its own, so it runs without transaction
+func (q *QbDB) withTxnFor(stmts int, fn func() error) error {
+	if stmts <= 1 {
+		return fn()
+	}
+	return q.withTxn(fn)
+}
+
+// withTxn runs fn in the active transaction, or begins, commits and rolls back its own one,
+// fn runs as is on executor set by UseExecutor that can't begin transaction, e.g. *sql.Tx of the caller
+func (q *QbDB) withTxn(fn func() error) error {
+	if q.Txn != nil && q.Txn.Tx != nil {
+		return fn()
+	}
+	if _, ok := q.executor.(txBeginner); q.executor != nil && !ok {
+		return fn()
+	}
+	tx, err := q.beginTx(nil, 0)
+	if err != nil {
+		return err
+	}
+	q.Txn = &QbTxn{QbDB: q, Tx: tx, Builder: q.Builder}
+	defer func() {
+		q.Txn = nil
+	}()
+	if err = fn(); err != nil {
+		if errTxn := q.Txn.Rollback(); errTxn != nil {
+			return errTxn
+		}
+		return err
+	}
+	return q.Txn.Commit()
+}
+
 // InTransaction executes fn passed as an argument in transaction mode
 // if there are no results returned - txn will be rolled back, otherwise committed and returned
+//
+// Deprecated: a successful fn returning nil or zero result is rolled back, use Transaction or Begin instead
 func (q *QbDB) InTransaction(fn func() (any, error)) error {
-	txn, err := q.Sql().Begin()
+	txn, err := q.beginTx(nil, 0)
 	if err != nil {
 		return err
 	}
 	// assign transaction and builder to Txn entity
 	q.Txn = &QbTxn{
+		QbDB:    q,
 		Tx:      txn,
 		Builder: q.Builder,
 	}
@@ -410,7 +424,7 @@ func (q *QbDB) InTransaction(fn func() (any, error)) error {
 	}()
 	result, err := fn()
 	if err != nil {
-		errTxn := txn.Rollback()
+		errTxn := q.Txn.Rollback()
 		if errTxn != nil {
 			return errTxn
 		}
@@ -441,11 +455,133 @@ func (q *QbDB) InTransaction(fn func() (any, error)) error {
 	}
 
 	if !isOk {
-		return txn.Rollback()
+		return q.Txn.Rollback()
 	}
-	err = txn.Commit()
+	return q.Txn.Commit()
+}
+
+// composeInsert builds INSERT stmt for one row with param bindings
+func (q *qbBuilder) composeInsert(data map[string]any) (string, []any, []string) {
+	columns, values, bindings, argColumns := prepareBindings(data)
+	query := `INSERT INTO "` + q.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `)`
+	return query + q.composeOnConflict(), values, argColumns
+}
+
+// composeInsertMany builds multi-row INSERT ... VALUES (...), (...) stmt for the given columns,
+// a key missing in a row is filled with DEFAULT
+func (q *qbBuilder) composeInsertMany(columns []string, rows []map[string]any) (string, []any, []string) {
+	query, values, argColumns := q.composeInsertValues(columns, rows)
+	return query + q.composeOnConflict(), values, argColumns
+}
+
+// composeInsertValues builds multi-row INSERT ... VALUES (...), (...) stmt without ON CONFLICT clause,
+// returning its values with the columns they are inserted into
+func (q *qbBuilder) composeInsertValues(columns []string, rows []map[string]any) (string, []any, []string) {
+	var values []any
+	var argColumns []string
+	tuples := make([]string, len(rows))
+	i := 1
+	for k, row := range rows {
+		bindings := make([]string, len(columns))
+		for c, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				bindings[c] = "DEFAULT"
+				continue
+			}
+			var bound []any
+			bindings[c], bound, i = bindValue(value, i)
+			values = append(values, bound...)
+			argColumns = append(argColumns, repeatColumn(unqualifiedColumn(column), len(bound))...)
+		}
+		tuples[k] = "(" + strings.Join(bindings, ", ") + ")"
+	}
+	query := `INSERT INTO "` + q.table + `" (` + strings.Join(columns, `, `) + `) VALUES ` + strings.Join(tuples, ", ")
+	return query, values, argColumns
+}
+
+// errOnConflictRaw is returned by stmts having their own ON CONFLICT clause or none at all (COPY)
+func errOnConflictRaw(operation string) error {
+	return fmt.Errorf("sql: OnConflictRaw is not supported by %s, use Insert/InsertMany or Upsert", operation)
+}
+
+// composeOnConflict builds ON CONFLICT clause set by OnConflictRaw
+func (q *qbBuilder) composeOnConflict() string {
+	if IsStringEmpty(q.onConflict) {
+		return ""
+	}
+	return " ON CONFLICT " + q.onConflict
+}
+
+// composeUpdate builds UPDATE stmt with corresponding where/from clauses, where bindings follow the SET ones,
+// joins are translated into FROM tables and where predicates
+func (q *qbBuilder) composeUpdate(data map[string]any) (string, []any, []string, error) {
+	columns, values, bindings, argColumns := prepareBindings(data)
+	setVal := ""
+	l := len(columns)
+	for k, col := range columns {
+		setVal += fmt.Sprintf("%s%s%s", col, " = ", bindings[k])
+		if k < l-1 {
+			setVal += ", "
+		}
+	}
+	tables, clauses, err := q.composeJoinedClauses(len(values) + 1)
 	if err != nil {
-		return err
+		return "", nil, nil, err
 	}
-	return nil
+	query := `UPDATE "` + q.table + `" SET ` + setVal
+	if IsStringNotEmpty(tables) {
+		query += fmt.Sprintf("%s%s", " FROM ", tables)
+	}
+	query += clauses
+	args, whereColumns := q.boundArgs()
+	return query, append(values, args...), append(argColumns, whereColumns...), nil
+}
+
+// composeReplace builds INSERT ... ON CONFLICT(conflict) DO UPDATE stmt for one row,
+// conflict key columns are left as is, DO NOTHING is used when there is nothing else to update,
+// so a conflicting row is not counted as affected then
+func (q *qbBuilder) composeReplace(data map[string]any, conflict string) (string, []any, []string, error) {
+	if IsStringNotEmpty(q.onConflict) {
+		return "", nil, nil, errOnConflictRaw("Replace")
+	}
+	columns, values, bindings, argColumns := prepareBindings(data)
+	query := `INSERT INTO "` + q.table + `" (` + strings.Join(columns, `, `) + `) VALUES(` + strings.Join(bindings, `, `) + `) ON CONFLICT(` + conflict + `)`
+	set := composeExcluded(columns, strings.Split(conflict, ","))
+	if len(set) == 0 {
+		return query + " DO NOTHING", values, argColumns, nil
+	}
+	return query + " DO UPDATE SET " + strings.Join(set, ", "), values, argColumns, nil
+}
+
+// composeExcluded builds col = excluded.col assignments for columns except the skipped ones
+func composeExcluded(columns []string, skip []string) []string {
+	skipped := make(map[string]bool, len(skip))
+	for _, column := range skip {
+		skipped[strings.TrimSpace(column)] = true
+	}
+	var set []string
+	for _, v := range columns {
+		if skipped[v] {
+			continue
+		}
+		set = append(set, fmt.Sprintf("%s%s%s", v, " = excluded.", v))
+	}
+	return set
+}
+
+// composeDelete builds DELETE stmt with corresponding where clause,
+// From table and joins are translated into USING tables and where predicates
+func (q *qbBuilder) composeDelete() (string, []any, []string, error) {
+	tables, clauses, err := q.composeJoinedClauses(q.startBindingsAt)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	query := `DELETE FROM "` + q.table + `"`
+	if IsStringNotEmpty(tables) {
+		query += fmt.Sprintf("%s%s", " USING ", tables)
+	}
+	query += clauses
+	args, columns := q.boundArgs()
+	return query, args, columns, nil
 }
@@ -0,0 +1,160 @@
+package qb
+
+import "strings"
+
+// WhereLike appends column LIKE pattern stmt to WHERE clause, pattern wildcards are kept as is
+func (q *QbDB) WhereLike(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorLike, pattern)
+}
+
+// AndWhereLike appends column LIKE pattern stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereLike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorLike, pattern)
+}
+
+// OrWhereLike appends column LIKE pattern stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereLike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorLike, pattern)
+}
+
+// WhereILike appends column ILIKE pattern stmt to WHERE clause (case-insensitive), pattern wildcards are kept as is
+func (q *QbDB) WhereILike(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorILike, pattern)
+}
+
+// AndWhereILike appends column ILIKE pattern stmt to WHERE clause (case-insensitive) with AND logical operator
+func (q *QbDB) AndWhereILike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorILike, pattern)
+}
+
+// OrWhereILike appends column ILIKE pattern stmt to WHERE clause (case-insensitive) with OR logical operator
+func (q *QbDB) OrWhereILike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorILike, pattern)
+}
+
+// WhereNotLike appends column NOT LIKE pattern stmt to WHERE clause, pattern wildcards are kept as is
+func (q *QbDB) WhereNotLike(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorNotLike, pattern)
+}
+
+// AndWhereNotLike appends column NOT LIKE pattern stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereNotLike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorNotLike, pattern)
+}
+
+// OrWhereNotLike appends column NOT LIKE pattern stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereNotLike(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorNotLike, pattern)
+}
+
+// WhereSimilarTo appends column SIMILAR TO pattern stmt to WHERE clause
+func (q *QbDB) WhereSimilarTo(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorSimilarTo, pattern)
+}
+
+// AndWhereSimilarTo appends column SIMILAR TO pattern stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereSimilarTo(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorSimilarTo, pattern)
+}
+
+// OrWhereSimilarTo appends column SIMILAR TO pattern stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereSimilarTo(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorSimilarTo, pattern)
+}
+
+// WhereStartsWith appends column LIKE 'value%' stmt to WHERE clause, % and _ in value are escaped
+func (q *QbDB) WhereStartsWith(column, value string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorLike, escapeLike(value)+likeWildcardAny)
+}
+
+// AndWhereStartsWith appends column LIKE 'value%' stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereStartsWith(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorLike, escapeLike(value)+likeWildcardAny)
+}
+
+// OrWhereStartsWith appends column LIKE 'value%' stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereStartsWith(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorLike, escapeLike(value)+likeWildcardAny)
+}
+
+// WhereEndsWith appends column LIKE '%value' stmt to WHERE clause, % and _ in value are escaped
+func (q *QbDB) WhereEndsWith(column, value string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorLike, likeWildcardAny+escapeLike(value))
+}
+
+// AndWhereEndsWith appends column LIKE '%value' stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereEndsWith(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorLike, likeWildcardAny+escapeLike(value))
+}
+
+// OrWhereEndsWith appends column LIKE '%value' stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereEndsWith(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorLike, likeWildcardAny+escapeLike(value))
+}
+
+// WhereContains appends column LIKE '%value%' stmt to WHERE clause, % and _ in value are escaped
+func (q *QbDB) WhereContains(column, value string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorLike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// AndWhereContains appends column LIKE '%value%' stmt to WHERE clause with AND logical operator
+func (q *QbDB) AndWhereContains(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorLike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// OrWhereContains appends column LIKE '%value%' stmt to WHERE clause with OR logical operator
+func (q *QbDB) OrWhereContains(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorLike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// WhereIContains appends column ILIKE '%value%' stmt to WHERE clause (case-insensitive), % and _ in value are escaped
+func (q *QbDB) WhereIContains(column, value string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorILike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// AndWhereIContains appends column ILIKE '%value%' stmt to WHERE clause (case-insensitive) with AND logical operator
+func (q *QbDB) AndWhereIContains(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorILike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// OrWhereIContains appends column ILIKE '%value%' stmt to WHERE clause (case-insensitive) with OR logical operator
+func (q *QbDB) OrWhereIContains(column, value string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorILike, likeWildcardAny+escapeLike(value)+likeWildcardAny)
+}
+
+// WhereRegex appends column ~ pattern stmt to WHERE clause (POSIX regular expression)
+func (q *QbDB) WhereRegex(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorRegex, pattern)
+}
+
+// AndWhereRegex appends column ~ pattern stmt to WHERE clause (POSIX regular expression) with AND logical operator
+func (q *QbDB) AndWhereRegex(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorRegex, pattern)
+}
+
+// OrWhereRegex appends column ~ pattern stmt to WHERE clause (POSIX regular expression) with OR logical operator
+func (q *QbDB) OrWhereRegex(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorRegex, pattern)
+}
+
+// WhereIRegex appends column ~* pattern stmt to WHERE clause (case-insensitive POSIX regular expression)
+func (q *QbDB) WhereIRegex(column, pattern string) *QbDB {
+	return q.buildWhere("", column, SqlOperatorIRegex, pattern)
+}
+
+// AndWhereIRegex appends column ~* pattern stmt to WHERE clause (case-insensitive POSIX regular expression) with AND logical operator
+func (q *QbDB) AndWhereIRegex(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorAnd, column, SqlOperatorIRegex, pattern)
+}
+
+// OrWhereIRegex appends column ~* pattern stmt to WHERE clause (case-insensitive POSIX regular expression) with OR logical operator
+func (q *QbDB) OrWhereIRegex(column, pattern string) *QbDB {
+	return q.buildWhere(SqlOperatorOr, column, SqlOperatorIRegex, pattern)
+}
+
+// escapeLike escapes LIKE wildcards in user input, so it is matched literally
+func escapeLike(value string) string {
+	value = strings.ReplaceAll(value, likeEscapeChar, likeEscapeChar+likeEscapeChar)
+	value = strings.ReplaceAll(value, likeWildcardAny, likeEscapeChar+likeWildcardAny)
+	return strings.ReplaceAll(value, likeWildcardCharacter, likeEscapeChar+likeWildcardCharacter)
+}
@@ -0,0 +1,63 @@
+package qb
+
+import "testing"
+
+func TestPatternPredicates(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name: "like, ilike, not like keep wildcards",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereLike("name", "a%").OrWhereILike("email", "%@x.io").AndWhereNotLike("code", "_b")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND name LIKE $1 OR email ILIKE $2 AND code NOT LIKE $3",
+			args: []any{"a%", "%@x.io", "_b"},
+		},
+		{
+			name: "starts, ends, contains escape user input",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereStartsWith("name", "50%").AndWhereEndsWith("path", `a_b`).OrWhereContains("note", `c\d`)
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND name LIKE $1 AND path LIKE $2 OR note LIKE $3",
+			args: []any{`50\%%`, `%a\_b`, `%c\\d%`},
+		},
+		{
+			name: "icontains uses ILIKE",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereIContains("name", "Jo")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND name ILIKE $1",
+			args: []any{"%Jo%"},
+		},
+		{
+			name: "similar to and regex",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereSimilarTo("sku", "(a|b)%").AndWhereRegex("name", "^J").OrWhereIRegex("name", "son$")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND sku SIMILAR TO $1 AND name ~ $2 OR name ~* $3",
+			args: []any{"(a|b)%", "^J", "son$"},
+		},
+		{
+			name: "injection stays a binding",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereContains("name", "'; DROP TABLE t; --")
+			},
+			sql:  "SELECT * FROM t WHERE 1=1  AND name LIKE $1",
+			args: []any{"%'; DROP TABLE t; --%"},
+		},
+	})
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"plain": "plain",
+		"100%":  `100\%`,
+		"a_b":   `a\_b`,
+		`a\b`:   `a\\b`,
+		`\%_`:   `\\\%\_`,
+	}
+	for in, want := range tests {
+		if got := escapeLike(in); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package qb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// registry holds named connections of Register/RegisterConn
+var registry = struct {
+	sync.RWMutex
+	conns       map[string]*QbConn
+	defaultName string
+}{conns: make(map[string]*QbConn)}
+
+// Register opens connection with the config and registers it by name, ex.:
+//
+//	err := qb.Register("reporting", qb.Config{DSN: os.Getenv("REPORTING_DSN")})
+//	...
+//	db, err := qb.Connection("reporting")
+//	rows, err := db.Table("daily_sales").Get()
+//
+// the first registered connection is the default one unless SetDefault is called
+func Register(name string, cfg Config) error {
+	conn, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+	if err = RegisterConn(name, conn); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	return nil
+}
+
+// RegisterConn registers opened connection by name, e.g. the one of NewQbCluster
+func RegisterConn(name string, conn *QbConn) error {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.conns[name]; ok {
+		return fmt.Errorf("sql: connection %q is already registered", name)
+	}
+	registry.conns[name] = conn
+	if IsStringEmpty(registry.defaultName) {
+		registry.defaultName = name
+	}
+	return nil
+}
+
+// SetDefault sets the registered connection returned by Default
+func SetDefault(name string) error {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.conns[name]; !ok {
+		return fmt.Errorf("sql: connection %q is not registered", name)
+	}
+	registry.defaultName = name
+	return nil
+}
+
+// Connection gets new QbDB of the connection registered by name, QbDB is not goroutine-safe, so get one per goroutine
+func Connection(name string) (*QbDB, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registeredConnection(name)
+}
+
+// Default gets new QbDB of the default registered connection
+func Default() (*QbDB, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	if IsStringEmpty(registry.defaultName) {
+		return nil, fmt.Errorf("sql: there are no registered connections")
+	}
+	return registeredConnection(registry.defaultName)
+}
+
+// registeredConnection gets new QbDB of the connection registered by name, registry must be locked
+func registeredConnection(name string) (*QbDB, error) {
+	conn, ok := registry.conns[name]
+	if !ok {
+		return nil, fmt.Errorf("sql: connection %q is not registered", name)
+	}
+	return NewQbDb(conn), nil
+}
+
+// CloseAll closes and unregisters all registered connections returning the first error of closing
+func CloseAll() error {
+	registry.Lock()
+	defer registry.Unlock()
+	var err error
+	for name, conn := range registry.conns {
+		if errClose := conn.Close(); errClose != nil && err == nil {
+			err = fmt.Errorf("sql: closing connection %q: %w", name, errClose)
+		}
+	}
+	registry.conns = make(map[string]*QbConn)
+	registry.defaultName = ""
+	return err
+}
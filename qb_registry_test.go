@@ -0,0 +1,190 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// resetRegistry closes registered connections after the test
+func resetRegistry(t *testing.T) {
+	t.Helper()
+	if err := CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = CloseAll() })
+}
+
+func TestRegistry(t *testing.T) {
+	cases := []struct {
+		name    string
+		setup   func(conns map[string]*QbConn) error
+		get     func() (*QbDB, error)
+		want    string // name of the connection got, empty for error
+		wantErr string
+	}{
+		{
+			name:    "default without connections",
+			get:     Default,
+			wantErr: "there are no registered connections",
+		},
+		{
+			name:  "first registered is default",
+			setup: func(conns map[string]*QbConn) error { return registerAll(conns, "main", "reporting") },
+			get:   Default,
+			want:  "main",
+		},
+		{
+			name: "SetDefault changes default",
+			setup: func(conns map[string]*QbConn) error {
+				if err := registerAll(conns, "main", "reporting"); err != nil {
+					return err
+				}
+				return SetDefault("reporting")
+			},
+			get:  Default,
+			want: "reporting",
+		},
+		{
+			name:  "connection by name",
+			setup: func(conns map[string]*QbConn) error { return registerAll(conns, "main", "reporting") },
+			get:   func() (*QbDB, error) { return Connection("reporting") },
+			want:  "reporting",
+		},
+		{
+			name:    "unknown connection",
+			setup:   func(conns map[string]*QbConn) error { return registerAll(conns, "main") },
+			get:     func() (*QbDB, error) { return Connection("missing") },
+			wantErr: `connection "missing" is not registered`,
+		},
+		{
+			name: "duplicate name",
+			setup: func(conns map[string]*QbConn) error {
+				if err := registerAll(conns, "main"); err != nil {
+					return err
+				}
+				return RegisterConn("main", newFakeDB().conn())
+			},
+			wantErr: `connection "main" is already registered`,
+		},
+		{
+			name: "SetDefault of unknown connection",
+			setup: func(conns map[string]*QbConn) error {
+				if err := registerAll(conns, "main"); err != nil {
+					return err
+				}
+				return SetDefault("missing")
+			},
+			wantErr: `connection "missing" is not registered`,
+		},
+		{
+			name: "CloseAll unregisters connections",
+			setup: func(conns map[string]*QbConn) error {
+				if err := registerAll(conns, "main"); err != nil {
+					return err
+				}
+				return CloseAll()
+			},
+			get:     func() (*QbDB, error) { return Connection("main") },
+			wantErr: `connection "main" is not registered`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resetRegistry(t)
+			conns := make(map[string]*QbConn)
+			var err error
+			if tc.setup != nil {
+				err = tc.setup(conns)
+			}
+			var db *QbDB
+			if err == nil && tc.get != nil {
+				db, err = tc.get()
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if db.Conn != conns[tc.want] {
+				t.Errorf("got another connection, want %q", tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	resetRegistry(t)
+	const goroutines = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*3)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("conn%d", i)
+			if err := RegisterConn(name, newFakeDB().conn()); err != nil {
+				errs <- err
+				return
+			}
+			db, err := Connection(name)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err = db.Table("t").Get(); err != nil {
+				errs <- err
+			}
+			if err = SetDefault(name); err != nil {
+				errs <- err
+			}
+			if _, err = Default(); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if err := RegisterConn("conn0", newFakeDB().conn()); err == nil {
+		t.Error("a name has been registered twice")
+	}
+}
+
+func TestRegistryConcurrentCloseAll(t *testing.T) {
+	resetRegistry(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = RegisterConn(fmt.Sprintf("conn%d", i), newFakeDB().conn())
+		}(i)
+		go func() {
+			defer wg.Done()
+			// the connections come and go, only a consistent error is allowed
+			if _, err := Default(); err != nil && !strings.Contains(err.Error(), "there are no registered connections") {
+				t.Error(err)
+			}
+			_ = CloseAll()
+		}()
+	}
+	wg.Wait()
+}
+
+func registerAll(conns map[string]*QbConn, names ...string) error {
+	for _, name := range names {
+		conns[name] = newFakeDB().conn()
+		if err := RegisterConn(name, conns[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
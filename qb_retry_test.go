@@ -0,0 +1,132 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "serialization failure", err: &pq.Error{Code: "40001"}, want: true},
+		{name: "deadlock", err: &pq.Error{Code: "40P01"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("update: %w", &pq.Error{Code: "40001"}), want: true},
+		{name: "mapped", err: mapError(&pq.Error{Code: "40P01"}), want: true},
+		{name: "unique violation", err: &pq.Error{Code: "23505"}},
+		{name: "plain error", err: errors.New("x")},
+		{name: "nil", err: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsRetryable(tc.err); got != tc.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		base     time.Duration
+		max      time.Duration
+		min, top time.Duration
+	}{
+		{attempt: 1, base: 10 * time.Millisecond, max: time.Second, min: 5 * time.Millisecond, top: 10 * time.Millisecond},
+		{attempt: 2, base: 10 * time.Millisecond, max: time.Second, min: 10 * time.Millisecond, top: 20 * time.Millisecond},
+		{attempt: 4, base: 10 * time.Millisecond, max: time.Second, min: 40 * time.Millisecond, top: 80 * time.Millisecond},
+		{attempt: 10, base: 10 * time.Millisecond, max: 100 * time.Millisecond, min: 50 * time.Millisecond, top: 100 * time.Millisecond},
+		{attempt: 1000, base: time.Second, max: time.Minute, min: 30 * time.Second, top: time.Minute},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.attempt), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				if got := retryDelay(tc.attempt, tc.base, tc.max); got < tc.min || got > tc.top {
+					t.Fatalf("retryDelay = %v, want within [%v, %v]", got, tc.min, tc.top)
+				}
+			}
+		})
+	}
+}
+
+func TestTransactionWithRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int // number of attempts failing at COMMIT
+		code     string
+		max      int
+		attempts int
+		retries  []int // attempts passed to OnRetry
+		wantErr  error
+	}{
+		{name: "succeeds first time", attempts: 1},
+		{name: "retries serialization failure", failures: 2, code: "40001", max: 3, attempts: 3, retries: []int{1, 2}},
+		{name: "retries deadlock", failures: 1, code: "40P01", max: 3, attempts: 2, retries: []int{1}},
+		{name: "gives up after max attempts", failures: 5, code: "40001", max: 2, attempts: 2, retries: []int{1}, wantErr: ErrSerialization},
+		{name: "does not retry other errors", failures: 1, code: "23505", max: 3, attempts: 1, wantErr: ErrUniqueViolation},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			commits := 0
+			fake.fail = func(query string) error {
+				if query != "COMMIT" {
+					return nil
+				}
+				if commits++; commits <= tc.failures {
+					return &pq.Error{Code: pq.ErrorCode(tc.code)}
+				}
+				return nil
+			}
+			var seen, retries []int
+			err := NewQbDb(fake.conn()).TransactionWithRetry(&RetryOptions{
+				MaxAttempts: tc.max,
+				BaseDelay:   time.Microsecond,
+				OnRetry:     func(attempt int, err error) { retries = append(retries, attempt) },
+			}, func(tx *QbTxn) error {
+				seen = append(seen, tx.Attempt())
+				return tx.Transaction(func(inner *QbTxn) error {
+					if inner.Attempt() != tx.Attempt() {
+						t.Errorf("nested attempt = %d, want %d", inner.Attempt(), tx.Attempt())
+					}
+					return nil
+				})
+			})
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if len(seen) != tc.attempts {
+				t.Errorf("attempts = %v, want %d", seen, tc.attempts)
+			}
+			for i, attempt := range seen {
+				if attempt != i+1 {
+					t.Errorf("attempt %d reported as %d", i+1, attempt)
+				}
+			}
+			if fmt.Sprint(retries) != fmt.Sprint(tc.retries) && !(len(retries) == 0 && len(tc.retries) == 0) {
+				t.Errorf("OnRetry attempts = %v, want %v", retries, tc.retries)
+			}
+		})
+	}
+}
+
+func TestTransactionWithRetryNestedRunsOnce(t *testing.T) {
+	fake := newFakeDB()
+	runs := 0
+	err := NewQbDb(fake.conn()).Transaction(func(tx *QbTxn) error {
+		return tx.TransactionWithRetry(nil, func(*QbTxn) error {
+			runs++
+			return &pq.Error{Code: "40001"}
+		})
+	})
+	if !IsRetryable(err) || runs != 1 {
+		t.Errorf("err = %v, runs = %d", err, runs)
+	}
+}
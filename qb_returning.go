@@ -0,0 +1,146 @@
+package qb
+
+import (
+	"strings"
+)
+
+// QbReturning executes Insert/InsertBatch/Update/Delete/Replace with RETURNING clause collecting affected rows
+type QbReturning struct {
+	db      *QbDB
+	columns []string
+}
+
+// Returning sets columns of RETURNING clause, ex.:
+//
+//	rows, err := db.Table("users").Where("id", "=", 5).Returning("id", "email").Update(data)
+//	err = rows.Scan(&users)
+//
+// pass "*" to return whole rows
+func (q *QbDB) Returning(columns ...string) *QbReturning {
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+	return &QbReturning{db: q, columns: columns}
+}
+
+// Insert inserts one row with param bindings returning it
+func (r *QbReturning) Insert(data map[string]any) (QbRows, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	builder := r.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	query, values, columns := builder.composeInsert(data)
+	return r.query(query, values, columns)
+}
+
+// InsertIf inserts one row with param bindings returning it
+func (r *QbReturning) InsertIf(ops *QbOps) (QbRows, error) {
+	return r.Insert(ops.GetArgs())
+}
+
+// InsertBatch inserts multiple rows returning them like InsertMany
+func (r *QbReturning) InsertBatch(data []map[string]any) (QbRows, error) {
+	return r.InsertMany(data)
+}
+
+// InsertMany inserts multiple rows returning them, columns are the union of all rows keys, missing values are filled with DEFAULT,
+// rows are split into several stmts under MaxBindings, that run in transaction if it is active or in its own one
+func (r *QbReturning) InsertMany(rows []map[string]any) (QbRows, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptyData
+	}
+	builder := r.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	columns := unionColumns(rows)
+	chunks := chunkRows(columns, rows, MaxBindings)
+	var result QbRows
+	err := r.db.withTxnFor(len(chunks), func() error {
+		for _, chunk := range chunks {
+			query, values, argColumns := builder.composeInsertMany(columns, chunk)
+			collected, err := r.query(query, values, argColumns)
+			if err != nil {
+				return err
+			}
+			result = append(result, collected...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update builds an UPDATE sql stmt with corresponding where/from clauses returning updated rows
+func (r *QbReturning) Update(data map[string]any) (QbRows, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	builder := r.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	query, values, columns, err := builder.composeUpdate(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.query(query, values, columns)
+}
+
+// UpdateIf builds an UPDATE sql stmt with corresponding where/from clauses returning updated rows
+func (r *QbReturning) UpdateIf(ops *QbOps) (QbRows, error) {
+	return r.Update(ops.GetArgs())
+}
+
+// Delete builds a DELETE stmt with corresponding where clause returning deleted rows
+func (r *QbReturning) Delete() (QbRows, error) {
+	builder := r.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	query, values, columns, err := builder.composeDelete()
+	if err != nil {
+		return nil, err
+	}
+	return r.query(query, values, columns)
+}
+
+// Replace inserts data if conflicting row hasn't been found, else it will update an existing one,
+// returning the inserted or updated row
+func (r *QbReturning) Replace(data map[string]any, conflict string) (QbRows, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	builder := r.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	query, values, columns, err := builder.composeReplace(data, conflict)
+	if err != nil {
+		return nil, err
+	}
+	return r.query(query, values, columns)
+}
+
+// ReplaceIf inserts data if conflicting row hasn't been found, else it will update an existing one,
+// returning the inserted or updated row
+func (r *QbReturning) ReplaceIf(ops *QbOps, conflict string) (QbRows, error) {
+	return r.Replace(ops.GetArgs(), conflict)
+}
+
+// appends RETURNING clause and collects rows, runs in transaction if it is active
+func (r *QbReturning) query(query string, values []any, columns []string) (QbRows, error) {
+	query += " RETURNING " + strings.Join(r.columns, ", ")
+	return r.db.bound(columns).queryRows(query, values...)
+}
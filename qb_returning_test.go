@@ -0,0 +1,121 @@
+package qb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReturning(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(q *QbDB) (QbRows, error)
+		sql  string
+	}{
+		{
+			name: "insert",
+			run: func(q *QbDB) (QbRows, error) {
+				return q.Table("users").Returning("id", "email").Insert(map[string]any{"email": "a@x.io"})
+			},
+			sql: `INSERT INTO "users" (email) VALUES($1) RETURNING id, email`,
+		},
+		{
+			name: "insert batch fills DEFAULT",
+			run: func(q *QbDB) (QbRows, error) {
+				return q.Table("users").Returning().InsertBatch([]map[string]any{{"email": "a@x.io"}, {"name": "b"}})
+			},
+			sql: `INSERT INTO "users" (email, name) VALUES ($1, DEFAULT), (DEFAULT, $2) RETURNING *`,
+		},
+		{
+			name: "update with where",
+			run: func(q *QbDB) (QbRows, error) {
+				return q.Table("users").Where("id", "=", 5).Returning("id").Update(map[string]any{"email": "a@x.io"})
+			},
+			sql: `UPDATE "users" SET email = $1 WHERE 1=1  AND id = $2 RETURNING id`,
+		},
+		{
+			name: "delete",
+			run: func(q *QbDB) (QbRows, error) {
+				return q.Table("users").Where("id", "=", 5).Returning("id").Delete()
+			},
+			sql: `DELETE FROM "users" WHERE 1=1  AND id = $1 RETURNING id`,
+		},
+		{
+			name: "replace",
+			run: func(q *QbDB) (QbRows, error) {
+				return q.Table("users").Returning("id").Replace(map[string]any{"email": "a@x.io"}, "email")
+			},
+			sql: `INSERT INTO "users" (email) VALUES($1) ON CONFLICT(email) DO NOTHING RETURNING id`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			fake.rows = func(string) ([]string, [][]driver.Value) {
+				return []string{"id", "email"}, [][]driver.Value{{int64(1), []byte("a@x.io")}}
+			}
+			rows, err := tc.run(NewQbDb(fake.conn()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.statements(); got[len(got)-1] != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", got[len(got)-1], tc.sql)
+			}
+			if len(rows) != 1 || rows[0]["id"] != int64(1) {
+				t.Errorf("rows = %v", rows)
+			}
+		})
+	}
+}
+
+func TestQbRowsScan(t *testing.T) {
+	type base struct {
+		ID int64 `db:"id"`
+	}
+	type user struct {
+		base
+		Email     string     `json:"email"`
+		Score     float64    `db:"score"`
+		Active    bool       `db:"active"`
+		Nick      *string    `db:"nick"`
+		DeletedAt *time.Time `db:"deleted_at"`
+		Loc       QbPoint    `db:"loc"`
+		Ignored   string     `db:"-"`
+	}
+	rows := QbRows{
+		{"id": int64(1), "email": "a@x.io", "score": "1.5", "active": "true", "nick": "al", "deleted_at": nil, "loc": "(1,2)", "ignored": "x"},
+		{"ID": int64(2), "EMAIL": "b@x.io", "score": int64(3)},
+	}
+	nick := "al"
+	want := []user{
+		{base: base{ID: 1}, Email: "a@x.io", Score: 1.5, Active: true, Nick: &nick, Loc: NewQbPoint(1, 2)},
+		{base: base{ID: 2}, Email: "b@x.io", Score: 3},
+	}
+	var users []user
+	if err := rows.Scan(&users); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("got %+v, want %+v", users, want)
+	}
+	var pointers []*user
+	if err := rows.Scan(&pointers); err != nil || len(pointers) != 2 || pointers[1].Email != "b@x.io" {
+		t.Errorf("pointers = %v, %v", pointers, err)
+	}
+	var first user
+	if err := rows.Scan(&first); err != nil || first.ID != 1 {
+		t.Errorf("first = %+v, %v", first, err)
+	}
+	if err := (QbRows{}).Scan(&first); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("empty rows err = %v", err)
+	}
+	if err := rows.Scan(users); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+	if err := (QbRows{{"id": "x"}}).Scan(&first); err == nil {
+		t.Error("expected error for non-numeric id")
+	}
+}
@@ -0,0 +1,186 @@
+package qb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// QbRows is the slice of column-value maps produced by queries with RETURNING, it can be scanned into structs
+type QbRows []map[string]any
+
+// Scan maps rows into dest by column names, dest is a pointer to a slice of structs (or struct pointers)
+// or a pointer to a struct which receives the first row, fields are matched by `db` tag, then by `json` tag,
+// then by case-insensitive field name
+func (r QbRows) Scan(dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("sql: scan destination must be a non-nil pointer, got %T", dest)
+	}
+	target := rv.Elem()
+	switch target.Kind() {
+	case reflect.Struct:
+		if len(r) == 0 {
+			return sql.ErrNoRows
+		}
+		return scanRow(r[0], target)
+	case reflect.Slice:
+		elemType := target.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			return fmt.Errorf("sql: scan destination must be a slice of structs, got %T", dest)
+		}
+		result := reflect.MakeSlice(target.Type(), 0, len(r))
+		for _, row := range r {
+			elem := reflect.New(elemType)
+			if err := scanRow(row, elem.Elem()); err != nil {
+				return err
+			}
+			if isPtr {
+				result = reflect.Append(result, elem)
+			} else {
+				result = reflect.Append(result, elem.Elem())
+			}
+		}
+		target.Set(result)
+		return nil
+	}
+	return fmt.Errorf("sql: unsupported scan destination %T", dest)
+}
+
+// scanRow assigns row values to the matching struct fields
+func scanRow(row map[string]any, target reflect.Value) error {
+	fields := structFields(target.Type())
+	for column, value := range row {
+		idx, ok := fields[strings.ToLower(column)]
+		if !ok {
+			continue
+		}
+		if err := assignValue(target.FieldByIndex(idx), value); err != nil {
+			return fmt.Errorf("sql: scan column %q: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// structFields maps lower-cased column names to field indices, embedded structs are flattened
+func structFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct { // exported fields of unexported embedded structs are promoted too
+			for name, idx := range structFields(f.Type) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = append([]int{i}, idx...)
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := tagName(f.Tag.Get("db"))
+		if IsStringEmpty(name) {
+			name = tagName(f.Tag.Get("json"))
+		}
+		if name == "-" {
+			continue
+		}
+		if IsStringEmpty(name) {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = []int{i}
+	}
+	return fields
+}
+
+func tagName(tag string) string {
+	return strings.TrimSpace(strings.Split(tag, ",")[0])
+}
+
+// assignValue sets value collected from database to the field converting compatible types
+func assignValue(field reflect.Value, value any) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(field.Type()) {
+		field.Set(v)
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		return assignString(field, s)
+	}
+	if isNumberKind(v.Kind()) && isNumberKind(field.Kind()) {
+		field.Set(v.Convert(field.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+}
+
+// assignString parses textual value (e.g. numeric) into the field
+func assignString(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("cannot assign string to %s", field.Type())
+		}
+		field.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("cannot assign string to %s", field.Type())
+	}
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
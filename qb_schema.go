@@ -152,6 +152,13 @@ func (q *QbTable) Index(indexName string) *QbTable {
 	return q
 }
 
+// GistIndex sets the last column to GiST index, to be used for geometric Point/Polygon columns
+func (q *QbTable) GistIndex(indexName string) *QbTable {
+	q.Index(indexName)
+	q.columns[len(q.columns)-1].IdxMethod = IndexMethodGist
+	return q
+}
+
 // Unique sets the last column to unique index
 func (q *QbTable) Unique(indexName string) *QbTable {
 	q.columns[len(q.columns)-1].IdxName = indexName
@@ -236,6 +243,12 @@ func (q *QbTable) Polygon(column string) *QbTable {
 	return q
 }
 
+// Array creates one-dimensional array column of elemType, ex.: Array("tags", TypeText) gives TEXT[]
+func (q *QbTable) Array(column, elemType string) *QbTable {
+	q.columns = append(q.columns, &qbColumn{Name: column, ColumnType: qbColType(elemType + TypeArray)})
+	return q
+}
+
 // Change the column type/length/nullable etc options
 func (q *QbTable) Change() {
 	q.columns[len(q.columns)-1].IsModify = true
@@ -286,8 +299,7 @@ func (q *QbDB) createTable(t *QbTable) (result sql.Result, err error) {
 		comments = append(comments, composeComment(t.tableName, col))
 	}
 	query += ")"
-	setCacheExecuteStmt(query)
-	result, err = q.Sql().Exec(query)
+	result, err = q.exec(query)
 	if err != nil {
 		return nil, err
 	}
@@ -332,8 +344,7 @@ func (q *QbDB) modifyTable(t *QbTable) (result sql.Result, err error) {
 			query += SemiColon
 		}
 	}
-	setCacheExecuteStmt(query)
-	result, err = q.Sql().Exec(query)
+	result, err = q.exec(query)
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +367,7 @@ func (q *QbDB) createIndices(indices []string) (result sql.Result, err error) {
 	}
 	for _, idx := range indices {
 		if IsStringNotEmpty(idx) {
-			result, err = q.Sql().Exec(idx)
+			result, err = q.exec(idx)
 			if err != nil {
 				return nil, err
 			}
@@ -368,7 +379,7 @@ func (q *QbDB) createIndices(indices []string) (result sql.Result, err error) {
 func (q *QbDB) createComments(comments []string) (result sql.Result, err error) {
 	for _, comment := range comments {
 		if IsStringNotEmpty(comment) {
-			result, err = q.Sql().Exec(comment)
+			result, err = q.exec(comment)
 			if err != nil {
 				return nil, err
 			}
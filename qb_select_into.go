@@ -0,0 +1,93 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InsertUsing inserts rows selected by sub query with INSERT INTO ... SELECT stmt returning the number of inserted rows, ex.:
+//
+//	sub := qb.NewQbDb(db.Conn).Table("users").Select("id", "email").Where("deleted_at", "<", cutoff)
+//	n, err := db.Table("users_archive").InsertUsing([]string{"user_id", "email"}, sub)
+//
+// sub must be built on its own QbDB, as the builder is shared by all calls on the same one
+func (q *QbDB) InsertUsing(columns []string, sub *QbDB) (int64, error) {
+	builder := q.Builder
+	if IsStringEmpty(builder.table) {
+		return 0, ErrNoTable
+	}
+	query, values, argColumns, err := composeSubQuery(sub)
+	if err != nil {
+		return 0, err
+	}
+	stmt := `INSERT INTO "` + builder.table + `"`
+	if len(columns) > 0 {
+		stmt += ` (` + strings.Join(columns, `, `) + `)`
+	}
+	stmt += " " + query + builder.composeOnConflict()
+	return q.bound(argColumns).execAffected(stmt, values...)
+}
+
+// CreateTableAs creates table filled with rows selected by sub query with CREATE [TEMPORARY] TABLE ... AS stmt,
+// returning the number of selected rows
+func (q *QbDB) CreateTableAs(name string, sub *QbDB, temporary bool) (int64, error) {
+	if IsStringEmpty(name) {
+		return 0, ErrNoTable
+	}
+	query, values, argColumns, err := composeSubQuery(sub)
+	if err != nil {
+		return 0, err
+	}
+	stmt := "CREATE "
+	if temporary {
+		stmt += "TEMPORARY "
+	}
+	stmt += `TABLE "` + name + `" AS ` + query
+	return q.bound(argColumns).execAffected(stmt, values...)
+}
+
+// SelectInto creates table filled with rows of the current select with SELECT ... INTO [TEMPORARY] stmt,
+// returning the number of selected rows, ex.:
+//
+//	n, err := db.Table("orders").Select("id", "total").Where("status", "=", "paid").SelectInto("paid_orders", true)
+func (q *QbDB) SelectInto(name string, temporary bool) (int64, error) {
+	builder := q.Builder
+	if IsStringEmpty(builder.table) || IsStringEmpty(name) {
+		return 0, ErrNoTable
+	}
+	if builder.err != nil {
+		return 0, builder.err
+	}
+	columns, next := builder.composeColumns()
+	into := ` INTO `
+	if temporary {
+		into += "TEMPORARY "
+	}
+	query := `SELECT ` + strings.Join(columns, `, `) + into + `"` + name + `" FROM ` + builder.table + builder.buildClausesAt(next)
+	args, argColumns := builder.selectBoundArgs()
+	return q.bound(argColumns).execAffected(query, args...)
+}
+
+// composeSubQuery builds SELECT stmt of sub query with its bindings started at 1 and the columns they are compared to
+func composeSubQuery(sub *QbDB) (string, []any, []string, error) {
+	if sub == nil || sub.Builder == nil || IsStringEmpty(sub.Builder.table) {
+		return "", nil, nil, ErrNoTable
+	}
+	if sub.Builder.err != nil {
+		return "", nil, nil, sub.Builder.err
+	}
+	if len(sub.Builder.union) > 0 {
+		return "", nil, nil, fmt.Errorf("sql: union sub queries are not supported")
+	}
+	args, columns := sub.Builder.selectBoundArgs()
+	return sub.Builder.buildSelect(), args, columns, nil
+}
+
+// execAffected executes query returning the number of affected rows
+func (q *QbDB) execAffected(query string, args ...any) (int64, error) {
+	res, err := q.exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
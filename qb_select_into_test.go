@@ -0,0 +1,82 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestInsertUsingAndCreateTableAs(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(db *QbDB, sub *QbDB) (int64, error)
+		sql  string
+		args []driver.Value
+	}{
+		{
+			name: "insert select with sub query bindings",
+			run: func(db *QbDB, sub *QbDB) (int64, error) {
+				return db.Table("users_archive").InsertUsing([]string{"user_id", "email"}, sub)
+			},
+			sql:  `INSERT INTO "users_archive" (user_id, email) SELECT id, email FROM users WHERE 1=1  AND deleted_at < $1 AND role = $2`,
+			args: []driver.Value{"2024-01-01", "guest"},
+		},
+		{
+			name: "insert select with on conflict and no columns",
+			run: func(db *QbDB, sub *QbDB) (int64, error) {
+				return db.Table("users_archive").OnConflictRaw("DO NOTHING").InsertUsing(nil, sub)
+			},
+			sql:  `INSERT INTO "users_archive" SELECT id, email FROM users WHERE 1=1  AND deleted_at < $1 AND role = $2 ON CONFLICT DO NOTHING`,
+			args: []driver.Value{"2024-01-01", "guest"},
+		},
+		{
+			name: "create temporary table as",
+			run: func(db *QbDB, sub *QbDB) (int64, error) {
+				return db.CreateTableAs("old_users", sub, true)
+			},
+			sql:  `CREATE TEMPORARY TABLE "old_users" AS SELECT id, email FROM users WHERE 1=1  AND deleted_at < $1 AND role = $2`,
+			args: []driver.Value{"2024-01-01", "guest"},
+		},
+		{
+			name: "select into keeps select and order bindings",
+			run: func(db *QbDB, _ *QbDB) (int64, error) {
+				return db.Table("orders").Select("id").
+					AddSelectCase(Case().When("total > ?", "big", 100).As("size")).
+					Where("status", "=", "paid").
+					SelectInto("paid_orders", false)
+			},
+			sql:  `SELECT id, CASE WHEN total > $1 THEN $2 END AS "size" INTO "paid_orders" FROM orders WHERE 1=1  AND status = $3`,
+			args: []driver.Value{int64(100), "big", "paid"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			conn := fake.conn()
+			sub := NewQbDb(conn).Table("users").Select("id", "email").Where("deleted_at", "<", "2024-01-01").AndWhere("role", "=", "guest")
+			if _, err := tc.run(NewQbDb(conn), sub); err != nil {
+				t.Fatal(err)
+			}
+			got := fake.queries[0]
+			if got.sql != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", got.sql, tc.sql)
+			}
+			if !reflect.DeepEqual(got.args, tc.args) {
+				t.Errorf("args = %#v, want %#v", got.args, tc.args)
+			}
+		})
+	}
+}
+
+func TestComposeSubQueryErrors(t *testing.T) {
+	db := newTestDB()
+	if _, _, _, err := composeSubQuery(nil); err != ErrNoTable {
+		t.Errorf("nil sub err = %v", err)
+	}
+	if _, _, _, err := composeSubQuery(NewQbDb(nil)); err != ErrNoTable {
+		t.Errorf("no table err = %v", err)
+	}
+	if _, _, _, err := composeSubQuery(db.Table("t").WhereColumn("a", "=", "1b")); err == nil {
+		t.Error("expected builder error")
+	}
+}
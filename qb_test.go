@@ -0,0 +1,206 @@
+package qb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// queryCase is the expected SELECT stmt and its bindings built by a QbDB chain
+type queryCase struct {
+	name  string
+	build func(q *QbDB) *QbDB
+	sql   string
+	args  []any
+}
+
+func newTestDB() *QbDB {
+	return NewQbDb(nil)
+}
+
+func runQueryCases(t *testing.T, cases []queryCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.build(newTestDB().Table("t"))
+			if sql := q.GetQuery(); sql != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", sql, tc.sql)
+			}
+			assertArgs(t, q.Builder.selectBindings(), tc.args)
+		})
+	}
+}
+
+// fakeDB is an in-memory driver recording executed statements, so queries are testable without a database
+type fakeDB struct {
+	mu      sync.Mutex
+	conns   int
+	queries []fakeQuery
+	// fail returns the error of statement, nil to succeed
+	fail func(query string) error
+	// rows returns the result set of query
+	rows func(query string) (columns []string, values [][]driver.Value)
+}
+
+type fakeQuery struct {
+	conn int // id of the connection stmt ran on
+	sql  string
+	args []driver.Value
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{}
+}
+
+// conn opens QbConn on the fake driver
+func (f *fakeDB) conn() *QbConn {
+	return NewQbConnWith(sql.OpenDB(f))
+}
+
+// statements gets sql of recorded statements in execution order
+func (f *fakeDB) statements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statements := make([]string, len(f.queries))
+	for i, query := range f.queries {
+		statements[i] = query.sql
+	}
+	return statements
+}
+
+func (f *fakeDB) open() driver.Conn {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.conns++
+	return &fakeConn{db: f, id: f.conns}
+}
+
+func (f *fakeDB) record(conn int, query string, args []driver.Value) error {
+	f.mu.Lock()
+	f.queries = append(f.queries, fakeQuery{conn: conn, sql: query, args: args})
+	fail := f.fail
+	f.mu.Unlock()
+	if fail != nil {
+		return fail(query)
+	}
+	return nil
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return f.open(), nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{db: f} }
+
+type fakeDriver struct{ db *fakeDB }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return d.db.open(), nil }
+
+type fakeConn struct {
+	db *fakeDB
+	id int
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, conn: c.id, query: query}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	if err := c.db.record(c.id, "BEGIN", nil); err != nil {
+		return nil, err
+	}
+	return &fakeTx{conn: c}, nil
+}
+
+type fakeTx struct{ conn *fakeConn }
+
+func (t *fakeTx) Commit() error   { return t.conn.db.record(t.conn.id, "COMMIT", nil) }
+func (t *fakeTx) Rollback() error { return t.conn.db.record(t.conn.id, "ROLLBACK", nil) }
+
+type fakeStmt struct {
+	db    *fakeDB
+	conn  int
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := s.db.record(s.conn, s.query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := s.db.record(s.conn, s.query, args); err != nil {
+		return nil, err
+	}
+	rows := &fakeRows{}
+	if s.db.rows != nil {
+		rows.columns, rows.values = s.db.rows(s.query)
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func assertArgs(t *testing.T, got, want []any) {
+	t.Helper()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestWhereFirstConditionGetsAnd(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name:  "single condition",
+			build: func(q *QbDB) *QbDB { return q.Where("id", "=", 1) },
+			sql:   "SELECT * FROM t WHERE 1=1  AND id = $1",
+			args:  []any{"1"},
+		},
+		{
+			name:  "first condition followed by OR",
+			build: func(q *QbDB) *QbDB { return q.Where("id", "=", 1).OrWhere("name", "=", "x") },
+			sql:   "SELECT * FROM t WHERE 1=1  AND id = $1 OR name = $2",
+			args:  []any{"1", "x"},
+		},
+		{
+			name:  "IN and IS NULL",
+			build: func(q *QbDB) *QbDB { return q.WhereIn("id", []int{1, 2}).AndWhereNull("deleted_at") },
+			sql:   "SELECT * FROM t WHERE 1=1  AND id IN ($1, $2) AND deleted_at IS NULL",
+			args:  []any{"1", "2"},
+		},
+	})
+}
+
+func TestPrintQueryWithExitReturns(t *testing.T) {
+	var out strings.Builder
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+	newTestDB().Table("t").Where("id", "=", 1).PrintQueryWithExit() // the test process would end here if it exited
+	if !strings.Contains(out.String(), "SELECT * FROM t WHERE 1=1  AND id = $1") {
+		t.Errorf("printed %q", out.String())
+	}
+}
@@ -0,0 +1,219 @@
+package qb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Begin starts transaction with isolation level and read-only mode of opts (nil for defaults), ex.:
+//
+//	tx, err := db.Begin(&sql.TxOptions{Isolation: sql.LevelSerializable})
+//	if err != nil {
+//		return err
+//	}
+//	defer tx.Rollback() // no-op after Commit
+//	if _, err = tx.Table("accounts").Where("id", "=", 1).Decrement("balance", 10); err != nil {
+//		return err
+//	}
+//	return tx.Commit()
+//
+// Begin called inside transaction starts nested one with SAVEPOINT, opts are ignored then,
+// QbTxn created as literal without QbDB can't start nested one
+func (q *QbDB) Begin(opts *sql.TxOptions) (*QbTxn, error) {
+	return q.begin(opts, 0)
+}
+
+// begin starts transaction of attempt of TransactionWithRetry, 0 outside of it
+func (q *QbDB) begin(opts *sql.TxOptions, attempt int) (*QbTxn, error) {
+	if q == nil {
+		return nil, errNilQbDB
+	}
+	if q.Txn != nil && q.Txn.Tx != nil {
+		return q.Txn.beginSavepoint()
+	}
+	tx, err := q.beginTx(opts, attempt)
+	if err != nil {
+		return nil, err
+	}
+	t := newQbTxn(q.Conn, tx, nil, "")
+	t.attempt = attempt
+	return t, nil
+}
+
+// Transaction runs fn in transaction committing it if fn returns nil, rolling it back if fn returns error or panics,
+// called inside transaction it runs fn in nested one with SAVEPOINT
+func (q *QbDB) Transaction(fn func(tx *QbTxn) error, opts ...*sql.TxOptions) error {
+	var opt *sql.TxOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return q.transaction(fn, opt, 0)
+}
+
+// transaction runs fn in transaction of attempt of TransactionWithRetry, 0 outside of it
+func (q *QbDB) transaction(fn func(tx *QbTxn) error, opts *sql.TxOptions, attempt int) (err error) {
+	tx, err := q.begin(opts, attempt)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err = fn(tx); err != nil {
+		if errTxn := tx.Rollback(); errTxn != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, errTxn)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Commit commits transaction or releases savepoint of nested one
+func (t *QbTxn) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	if t.parent != nil {
+		_, err := t.db().exec("RELEASE SAVEPOINT " + t.savepoint)
+		return err
+	}
+	return t.db().endTx("COMMIT", t.Tx.Commit)
+}
+
+// Rollback rolls back transaction or rolls back to savepoint of nested one,
+// it returns sql.ErrTxDone after Commit/Rollback, so it is safe to defer
+func (t *QbTxn) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	if t.parent != nil {
+		_, err := t.db().exec("ROLLBACK TO SAVEPOINT " + t.savepoint)
+		return err
+	}
+	return t.db().endTx("ROLLBACK", t.Tx.Rollback)
+}
+
+// beginSavepoint starts nested transaction with SAVEPOINT named by the counter of the outermost transaction,
+// so sibling savepoints get distinct names
+func (t *QbTxn) beginSavepoint() (*QbTxn, error) {
+	if t.done {
+		return nil, sql.ErrTxDone
+	}
+	root := t
+	for root.parent != nil {
+		root = root.parent
+	}
+	root.savepoints++
+	savepoint := fmt.Sprintf("qb_sp_%d", root.savepoints)
+	if _, err := t.db().exec("SAVEPOINT " + savepoint); err != nil {
+		return nil, err
+	}
+	return newQbTxn(t.Conn, t.Tx, t, savepoint), nil
+}
+
+// db gets QbDB running in transaction, QbTxn created as literal gets the one sharing its builder
+func (t *QbTxn) db() *QbDB {
+	if t.QbDB == nil {
+		t.QbDB = &QbDB{Builder: t.Builder, Txn: t}
+	}
+	return t.QbDB
+}
+
+// newQbTxn creates transaction with its own builder, QbDB methods called on it run in tx
+func newQbTxn(conn *QbConn, tx *sql.Tx, parent *QbTxn, savepoint string) *QbTxn {
+	db := NewQbDb(conn)
+	t := &QbTxn{QbDB: db, Tx: tx, Builder: db.Builder, parent: parent, savepoint: savepoint}
+	db.Txn = t
+	return t
+}
+
+// RetryOptions sets how TransactionWithRetry retries transaction on serialization failures and deadlocks
+type RetryOptions struct {
+	TxOptions   *sql.TxOptions               // isolation level and read-only mode
+	MaxAttempts int                          // attempts including the first one, 3 by default
+	BaseDelay   time.Duration                // delay before the 2nd attempt doubled for each next one, 10ms by default
+	MaxDelay    time.Duration                // cap of delay, 1s by default
+	OnRetry     func(attempt int, err error) // called before each retry with the number of the failed attempt
+}
+
+// TransactionWithRetry runs fn in transaction like Transaction, re-running the whole transaction from scratch
+// when it fails with SQLSTATE 40001 (serialization_failure) or 40P01 (deadlock_detected), ex.:
+//
+//	err := db.TransactionWithRetry(&qb.RetryOptions{
+//		TxOptions:   &sql.TxOptions{Isolation: sql.LevelSerializable},
+//		MaxAttempts: 5,
+//	}, func(tx *qb.QbTxn) error {
+//		log.Println("attempt", tx.Attempt())
+//		...
+//	})
+//
+// fn may be run several times, so it must not have side effects outside transaction,
+// called inside transaction fn runs once in a nested one, as only the outermost transaction can be retried
+func (q *QbDB) TransactionWithRetry(opts *RetryOptions, fn func(tx *QbTxn) error) error {
+	if q == nil {
+		return errNilQbDB
+	}
+	if opts == nil {
+		opts = &RetryOptions{}
+	}
+	if q.Txn != nil && q.Txn.Tx != nil {
+		return q.Transaction(fn)
+	}
+	maxAttempts, baseDelay, maxDelay := opts.MaxAttempts, opts.BaseDelay, opts.MaxDelay
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if baseDelay <= 0 {
+		baseDelay = 10 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = time.Second
+	}
+	for attempt := 1; ; attempt++ {
+		err := q.transaction(fn, opts.TxOptions, attempt)
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) {
+			return err
+		}
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt, err)
+		}
+		time.Sleep(retryDelay(attempt, baseDelay, maxDelay))
+	}
+}
+
+// retryDelay gets the delay after failed attempt: base doubled for each next attempt and capped by max,
+// with jitter within [delay/2, delay]
+func retryDelay(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Attempt gets the number of attempt of TransactionWithRetry the transaction runs in, 1 for the first one
+func (t *QbTxn) Attempt() int {
+	if t.attempt == 0 && t.parent != nil {
+		return t.parent.Attempt()
+	}
+	if t.attempt == 0 {
+		return 1
+	}
+	return t.attempt
+}
+
+// IsRetryable reports whether err is serialization failure or deadlock, so the transaction can be retried
+func IsRetryable(err error) bool {
+	return errors.Is(mapError(err), ErrSerialization) || errors.Is(mapError(err), ErrDeadlock)
+}
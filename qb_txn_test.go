@@ -0,0 +1,121 @@
+package qb
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTransaction(t *testing.T) {
+	errFail := errors.New("fail")
+	tests := []struct {
+		name       string
+		fn         func(tx *QbTxn) error
+		err        error
+		statements []string
+	}{
+		{
+			name: "commit on nil error",
+			fn: func(tx *QbTxn) error {
+				return tx.Table("t").Insert(map[string]any{"a": 1})
+			},
+			statements: []string{"BEGIN", `INSERT INTO "t" (a) VALUES($1)`, "COMMIT"},
+		},
+		{
+			name:       "rollback on error",
+			fn:         func(tx *QbTxn) error { return errFail },
+			err:        errFail,
+			statements: []string{"BEGIN", "ROLLBACK"},
+		},
+		{
+			name: "nested transactions use savepoints",
+			fn: func(tx *QbTxn) error {
+				if err := tx.Transaction(func(inner *QbTxn) error {
+					return inner.Transaction(func(*QbTxn) error { return nil })
+				}); err != nil {
+					return err
+				}
+				_ = tx.Transaction(func(*QbTxn) error { return errFail })
+				return nil
+			},
+			statements: []string{
+				"BEGIN",
+				"SAVEPOINT qb_sp_1", "SAVEPOINT qb_sp_2", "RELEASE SAVEPOINT qb_sp_2", "RELEASE SAVEPOINT qb_sp_1",
+				"SAVEPOINT qb_sp_3", "ROLLBACK TO SAVEPOINT qb_sp_3",
+				"COMMIT",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			err := NewQbDb(fake.conn()).Transaction(tc.fn)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err = %v, want %v", err, tc.err)
+			}
+			if got := fake.statements(); !reflect.DeepEqual(got, tc.statements) {
+				t.Errorf("statements:\n got: %q\nwant: %q", got, tc.statements)
+			}
+		})
+	}
+}
+
+func TestTransactionPanicRollsBack(t *testing.T) {
+	fake := newFakeDB()
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Fatalf("recovered %v", p)
+		}
+		if got, want := fake.statements(), []string{"BEGIN", "ROLLBACK"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("statements = %q, want %q", got, want)
+		}
+	}()
+	_ = NewQbDb(fake.conn()).Transaction(func(*QbTxn) error { panic("boom") })
+}
+
+func TestTxnDone(t *testing.T) {
+	fake := newFakeDB()
+	tx, err := NewQbDb(fake.conn()).Begin(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != sql.ErrTxDone {
+		t.Errorf("rollback after commit = %v", err)
+	}
+	if err := tx.Commit(); err != sql.ErrTxDone {
+		t.Errorf("second commit = %v", err)
+	}
+	if _, err := tx.Begin(nil); err != sql.ErrTxDone {
+		t.Errorf("savepoint after commit = %v", err)
+	}
+}
+
+func TestTxnLiteralWithoutQbDB(t *testing.T) {
+	fake := newFakeDB()
+	tx, err := NewQbDb(fake.conn()).Sql().Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	tests := []struct {
+		name string
+		run  func(txn *QbTxn) error
+	}{
+		{name: "Begin", run: func(txn *QbTxn) error { _, err := txn.Begin(nil); return err }},
+		{name: "Transaction", run: func(txn *QbTxn) error { return txn.Transaction(func(*QbTxn) error { return nil }) }},
+		{name: "TransactionWithRetry", run: func(txn *QbTxn) error {
+			return txn.TransactionWithRetry(nil, func(*QbTxn) error { return nil })
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.run(&QbTxn{Tx: tx, Builder: newBuilder()}); !errors.Is(err, errNilQbDB) {
+				t.Errorf("err = %v, want errNilQbDB", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package qb
+
+import (
+	"sort"
+	"strings"
+)
+
+// QbUpsert builds INSERT ... ON CONFLICT stmt with the given conflict target and action, ex.:
+//
+//	res, err := db.Table("users").Upsert().
+//		OnConflict("email").
+//		DoUpdate("name", "age").
+//		DoUpdateSet(map[string]any{"updated_at": qb.Expr("NOW()")}).
+//		Where("users.deleted_at IS NULL").
+//		Exec(data)
+//	fmt.Println(res.Inserted, res.Updated)
+type QbUpsert struct {
+	db          *QbDB
+	target      string
+	doNothing   bool
+	updateCols  []string
+	updateSet   map[string]any
+	updateWhere *qbClause
+}
+
+// QbUpsertResult holds the number of inserted and updated rows of upsert,
+// rows skipped by DO NOTHING or by the update Where are in neither of them
+type QbUpsertResult struct {
+	Inserted int64
+	Updated  int64
+}
+
+// Upsert starts INSERT ... ON CONFLICT stmt for the table
+func (q *QbDB) Upsert() *QbUpsert {
+	return &QbUpsert{db: q}
+}
+
+// OnConflict sets conflict target columns (unique index inference)
+func (u *QbUpsert) OnConflict(columns ...string) *QbUpsert {
+	u.target = "(" + strings.Join(columns, ", ") + ")"
+	return u
+}
+
+// OnConstraint sets conflict target by constraint name
+func (u *QbUpsert) OnConstraint(name string) *QbUpsert {
+	u.target = "ON CONSTRAINT " + name
+	return u
+}
+
+// DoNothing skips conflicting rows
+func (u *QbUpsert) DoNothing() *QbUpsert {
+	u.doNothing = true
+	return u
+}
+
+// DoUpdate updates conflicting rows setting the given columns to the inserted (excluded) values,
+// with no columns (and no DoUpdateSet) all inserted columns except conflict target ones are updated
+func (u *QbUpsert) DoUpdate(columns ...string) *QbUpsert {
+	u.doNothing = false
+	u.updateCols = append(u.updateCols, columns...)
+	return u
+}
+
+// DoUpdateSet updates conflicting rows setting columns to values, *QbExpr values are rendered inline
+// and may refer to the existing row by table name and to the inserted one by excluded
+func (u *QbUpsert) DoUpdateSet(data map[string]any) *QbUpsert {
+	u.doNothing = false
+	if u.updateSet == nil {
+		u.updateSet = make(map[string]any, len(data))
+	}
+	for column, value := range data {
+		u.updateSet[column] = value
+	}
+	return u
+}
+
+// Where sets condition of DO UPDATE action, conflicting rows not matching it are left as is, ex.:
+//
+//	Where("users.version < excluded.version")
+func (u *QbUpsert) Where(sql string, args ...any) *QbUpsert {
+	u.updateWhere = &qbClause{sql: sql, args: args}
+	return u
+}
+
+// Exec inserts one row or updates the conflicting one
+func (u *QbUpsert) Exec(data map[string]any) (*QbUpsertResult, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	return u.ExecMany([]map[string]any{data})
+}
+
+// ExecMany inserts multiple rows or updates the conflicting ones, rows are split into several stmts under MaxBindings,
+// that run in transaction if it is active or in its own one, rows fitting in one stmt run without starting transaction
+func (u *QbUpsert) ExecMany(rows []map[string]any) (*QbUpsertResult, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptyData
+	}
+	builder := u.db.Builder
+	if IsStringEmpty(builder.table) {
+		return nil, ErrNoTable
+	}
+	if IsStringEmpty(u.target) && !u.doNothing {
+		return nil, errUpsertWithoutTarget
+	}
+	columns := unionColumns(rows)
+	limit := MaxBindings - u.countBindings()
+	chunks := chunkRows(columns, rows, limit)
+	result := &QbUpsertResult{}
+	err := u.db.withTxnFor(len(chunks), func() error {
+		for _, chunk := range chunks {
+			query, values, argColumns := builder.composeInsertValues(columns, chunk)
+			action, args, actionColumns := u.composeAction(columns, len(values)+1)
+			query += action + " RETURNING (xmax = 0) AS inserted"
+			collected, err := u.db.bound(append(argColumns, actionColumns...)).queryRows(query, append(values, args...)...)
+			if err != nil {
+				return err
+			}
+			for _, row := range collected {
+				if inserted, _ := row["inserted"].(bool); inserted {
+					result.Inserted++
+				} else {
+					result.Updated++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// composeAction builds ON CONFLICT clause with bindings started at startedAt,
+// returning its args with the columns they are set to
+func (u *QbUpsert) composeAction(columns []string, startedAt int) (string, []any, []string) {
+	clause := " ON CONFLICT"
+	if IsStringNotEmpty(u.target) {
+		clause += " " + u.target
+	}
+	if u.doNothing {
+		return clause + " DO NOTHING", nil, nil
+	}
+	var set []string
+	if len(u.updateCols) > 0 {
+		set = composeExcluded(u.updateCols, nil)
+	} else if len(u.updateSet) == 0 {
+		set = composeExcluded(columns, u.targetColumns())
+	}
+	var args []any
+	var argColumns []string
+	next := startedAt
+	keys := make([]string, 0, len(u.updateSet))
+	for column := range u.updateSet {
+		keys = append(keys, column)
+	}
+	sort.Strings(keys)
+	for _, column := range keys {
+		var binding string
+		var bound []any
+		binding, bound, next = bindValue(u.updateSet[column], next)
+		set = append(set, column+" = "+binding)
+		args = append(args, bound...)
+		argColumns = append(argColumns, repeatColumn(unqualifiedColumn(column), len(bound))...)
+	}
+	if len(set) == 0 {
+		return clause + " DO NOTHING", args, argColumns
+	}
+	clause += " DO UPDATE SET " + strings.Join(set, ", ")
+	if u.updateWhere != nil {
+		var where string
+		where, _ = renderPlaceholders(u.updateWhere.sql, next)
+		clause += " WHERE " + where
+		whereArgs := prepareClauseArgs(u.updateWhere)
+		args = append(args, whereArgs...)
+		argColumns = append(argColumns, repeatColumn("", len(whereArgs))...)
+	}
+	return clause, args, argColumns
+}
+
+// targetColumns gets columns of OnConflict target, nil for OnConstraint one
+func (u *QbUpsert) targetColumns() []string {
+	if !strings.HasPrefix(u.target, "(") {
+		return nil
+	}
+	return strings.Split(strings.Trim(u.target, "()"), ",")
+}
+
+// countBindings counts bindings of update action, so that chunks of rows leave room for them
+func (u *QbUpsert) countBindings() int {
+	n := 0
+	for _, value := range u.updateSet {
+		n += countBindings(value)
+	}
+	if u.updateWhere != nil {
+		n += len(u.updateWhere.args)
+	}
+	return n
+}
@@ -0,0 +1,180 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestComposeReplace(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]any
+		conflict string
+		sql      string
+		args     []any
+	}{
+		{
+			name:     "conflict column is not updated",
+			data:     map[string]any{"email": "a@x.io"},
+			conflict: "id",
+			sql:      `INSERT INTO "t" (email) VALUES($1) ON CONFLICT(id) DO UPDATE SET email = excluded.email`,
+			args:     []any{"a@x.io"},
+		},
+		{
+			// regression: this used to be DO UPDATE SET email = excluded.email, which counted the row as affected
+			name:     "conflict columns only give DO NOTHING",
+			data:     map[string]any{"email": "a@x.io"},
+			conflict: "email",
+			sql:      `INSERT INTO "t" (email) VALUES($1) ON CONFLICT(email) DO NOTHING`,
+			args:     []any{"a@x.io"},
+		},
+		{
+			name:     "composite conflict with spaces",
+			data:     map[string]any{"user_id": 1},
+			conflict: "user_id, group_id",
+			sql:      `INSERT INTO "t" (user_id) VALUES($1) ON CONFLICT(user_id, group_id) DO NOTHING`,
+			args:     []any{"1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, _, err := newTestDB().Table("t").Builder.composeReplace(tc.data, tc.conflict)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", query, tc.sql)
+			}
+			assertArgs(t, args, tc.args)
+		})
+	}
+}
+
+func TestReplaceConflictOnlyAffectsNothing(t *testing.T) {
+	fake := newFakeDB()
+	if _, err := NewQbDb(fake.conn()).Table("t").Replace(map[string]any{"email": "a@x.io"}, "email"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fake.statements()[0], `INSERT INTO "t" (email) VALUES($1) ON CONFLICT(email) DO NOTHING`; got != want {
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestUpsertComposeAction(t *testing.T) {
+	columns := []string{"email", "name", "visits"}
+	tests := []struct {
+		name  string
+		build func(u *QbUpsert) *QbUpsert
+		start int
+		sql   string
+		args  []any
+	}{
+		{
+			name:  "all columns but target",
+			build: func(u *QbUpsert) *QbUpsert { return u.OnConflict("email").DoUpdate() },
+			start: 4,
+			sql:   " ON CONFLICT (email) DO UPDATE SET name = excluded.name, visits = excluded.visits",
+		},
+		{
+			name:  "do nothing without target",
+			build: func(u *QbUpsert) *QbUpsert { return u.DoNothing() },
+			start: 4,
+			sql:   " ON CONFLICT DO NOTHING",
+		},
+		{
+			name:  "selected columns on constraint",
+			build: func(u *QbUpsert) *QbUpsert { return u.OnConstraint("users_email_key").DoUpdate("name") },
+			start: 4,
+			sql:   " ON CONFLICT ON CONSTRAINT users_email_key DO UPDATE SET name = excluded.name",
+		},
+		{
+			name: "set expressions and where continue numbering",
+			build: func(u *QbUpsert) *QbUpsert {
+				return u.OnConflict("email").
+					DoUpdate("name").
+					DoUpdateSet(map[string]any{"visits": Expr("t.visits + ?", 1), "note": "dup"}).
+					Where("t.version < ?", 3)
+			},
+			start: 4,
+			sql:   " ON CONFLICT (email) DO UPDATE SET name = excluded.name, note = $4, visits = t.visits + $5 WHERE t.version < $6",
+			args:  []any{"dup", 1, 3},
+		},
+		{
+			name:  "target covering all columns gives DO NOTHING",
+			build: func(u *QbUpsert) *QbUpsert { return u.OnConflict("email", "name", "visits").DoUpdate() },
+			start: 1,
+			sql:   " ON CONFLICT (email, name, visits) DO NOTHING",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u := tc.build(newTestDB().Table("t").Upsert())
+			sql, args, _ := u.composeAction(columns, tc.start)
+			if sql != tc.sql {
+				t.Errorf("sql:\n got: %s\nwant: %s", sql, tc.sql)
+			}
+			assertArgs(t, args, tc.args)
+		})
+	}
+}
+
+func TestUpsertCountsInsertedAndUpdated(t *testing.T) {
+	fake := newFakeDB()
+	fake.rows = func(string) ([]string, [][]driver.Value) {
+		return []string{"inserted"}, [][]driver.Value{{true}, {false}, {true}}
+	}
+	res, err := NewQbDb(fake.conn()).Table("t").Upsert().OnConflict("email").
+		ExecMany([]map[string]any{{"email": "a"}, {"email": "b", "name": "B"}, {"email": "c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Inserted != 2 || res.Updated != 1 {
+		t.Errorf("result = %+v", res)
+	}
+	want := `INSERT INTO "t" (email, name) VALUES ($1, DEFAULT), ($2, $3), ($4, DEFAULT) ON CONFLICT (email) DO UPDATE SET name = excluded.name RETURNING (xmax = 0) AS inserted`
+	if got := fake.statements(); len(got) != 1 || got[0] != want { // one stmt runs without BEGIN/COMMIT
+		t.Errorf("sql:\n got: %s\nwant: %s", got, want)
+	}
+	if _, err := NewQbDb(fake.conn()).Table("t").Upsert().DoUpdate().Exec(map[string]any{"a": 1}); err == nil {
+		t.Error("expected error for DO UPDATE without target")
+	}
+}
+
+func TestUpsertStartsTransactionForSeveralStmts(t *testing.T) {
+	many := make([]map[string]any, MaxBindings+1)
+	for i := range many {
+		many[i] = map[string]any{"email": i}
+	}
+	tests := []struct {
+		name string
+		run  func(u *QbUpsert) error
+		want []string
+	}{
+		{
+			name: "Exec",
+			run:  func(u *QbUpsert) error { _, err := u.Exec(map[string]any{"email": "a"}); return err },
+			want: []string{"INSERT"},
+		},
+		{
+			name: "ExecMany several stmts",
+			run:  func(u *QbUpsert) error { _, err := u.ExecMany(many); return err },
+			want: []string{"BEGIN", "INSERT", "INSERT", "COMMIT"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDB()
+			if err := tc.run(NewQbDb(fake.conn()).Table("t").Upsert().OnConflict("email").DoNothing()); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, query := range fake.statements() {
+				got = append(got, queryOperation(query))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("statements %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // WhereExists constructs one builder from another to implement WHERE EXISTS sql/dml clause
 func (q *QbDB) WhereExists(db *QbDB) *QbDB {
@@ -442,6 +445,56 @@ func (q *QbDB) AndWhereNotNullIf(fnc func() bool, field string) *QbDB {
 	return q.AndWhereNotNull(field)
 }
 
+// WhereColumn compares two columns, ex.: WhereColumn("updated_at", ">", "created_at"),
+// both identifiers are validated and quoted, the operator has to be a comparison one
+func (q *QbDB) WhereColumn(left, operator, right string) *QbDB {
+	return q.buildWhereColumn("", left, operator, right)
+}
+
+// AndWhereColumn compares two columns with AND logical operator
+func (q *QbDB) AndWhereColumn(left, operator, right string) *QbDB {
+	return q.buildWhereColumn(SqlOperatorAnd, left, operator, right)
+}
+
+// OrWhereColumn compares two columns with OR logical operator
+func (q *QbDB) OrWhereColumn(left, operator, right string) *QbDB {
+	return q.buildWhereColumn(SqlOperatorOr, left, operator, right)
+}
+
+// WhereColumns compares several pairs of columns joined by AND, each pair is {left, operator, right}
+func (q *QbDB) WhereColumns(pairs ...[3]string) *QbDB {
+	for _, pair := range pairs {
+		q.AndWhereColumn(pair[0], pair[1], pair[2])
+	}
+	return q
+}
+
+// OrWhereColumns compares several pairs of columns joined by AND as one group with OR logical operator
+func (q *QbDB) OrWhereColumns(pairs ...[3]string) *QbDB {
+	conditions := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		condition, err := composeColumnComparison(pair[0], pair[1], pair[2])
+		if err != nil {
+			q.Builder.setErr(err)
+			return q
+		}
+		conditions = append(conditions, condition)
+	}
+	if len(conditions) == 0 {
+		return q
+	}
+	return q.buildWhereClause(SqlOperatorOr, "("+strings.Join(conditions, And)+")")
+}
+
+func (q *QbDB) buildWhereColumn(prefix, left, operator, right string) *QbDB {
+	condition, err := composeColumnComparison(left, operator, right)
+	if err != nil {
+		q.Builder.setErr(err)
+		return q
+	}
+	return q.buildWhereClause(prefix, condition)
+}
+
 func (q *QbDB) buildWhere(prefix, operand, operator string, value any) *QbDB {
 	if IsStringNotEmpty(prefix) {
 		prefix = fmt.Sprintf("%s%s%s", " ", prefix, " ")
@@ -449,3 +502,20 @@ func (q *QbDB) buildWhere(prefix, operand, operator string, value any) *QbDB {
 	q.Builder.whereBindings = append(q.Builder.whereBindings, map[string]any{prefix + operand + " " + operator: value})
 	return q
 }
+
+func (q *QbDB) buildHaving(prefix, operand, operator string, value any) *QbDB {
+	if IsStringNotEmpty(prefix) {
+		prefix = fmt.Sprintf("%s%s%s", " ", prefix, " ")
+	}
+	q.Builder.havingBindings = append(q.Builder.havingBindings, map[string]any{prefix + operand + " " + operator: value})
+	return q
+}
+
+// buildWhereClause appends raw condition with ? placeholders bound to args in order
+func (q *QbDB) buildWhereClause(prefix, sql string, args ...any) *QbDB {
+	if IsStringNotEmpty(prefix) {
+		prefix = fmt.Sprintf("%s%s%s", " ", prefix, " ")
+	}
+	q.Builder.whereBindings = append(q.Builder.whereBindings, map[string]any{prefix: &qbClause{sql: sql, args: args}})
+	return q
+}
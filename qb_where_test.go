@@ -0,0 +1,87 @@
+package qb
+
+import "testing"
+
+func TestWhereColumn(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name:  "single comparison quotes identifiers",
+			build: func(q *QbDB) *QbDB { return q.WhereColumn("updated_at", ">", "created_at") },
+			sql:   `SELECT * FROM t WHERE 1=1  AND "updated_at" > "created_at"`,
+		},
+		{
+			name: "mixed with bound conditions keeps order and numbering",
+			build: func(q *QbDB) *QbDB {
+				return q.Where("id", ">", 10).OrWhereColumn("t.a", "<>", "u.b").AndWhere("name", "=", "x")
+			},
+			sql:  `SELECT * FROM t WHERE 1=1  AND id > $1 OR "t"."a" <> "u"."b" AND name = $2`,
+			args: []any{"10", "x"},
+		},
+		{
+			name: "pairs joined by AND",
+			build: func(q *QbDB) *QbDB {
+				return q.WhereColumns([3]string{"a", "=", "b"}, [3]string{"c", "<=", "d"})
+			},
+			sql: `SELECT * FROM t WHERE 1=1  AND "a" = "b" AND "c" <= "d"`,
+		},
+		{
+			name: "or group of pairs",
+			build: func(q *QbDB) *QbDB {
+				return q.Where("id", "=", 1).OrWhereColumns([3]string{"a", "=", "b"}, [3]string{"c", "is distinct from", "d"})
+			},
+			sql:  `SELECT * FROM t WHERE 1=1  AND id = $1 OR ("a" = "b" AND "c" IS DISTINCT FROM "d")`,
+			args: []any{"1"},
+		},
+	})
+}
+
+func TestWhereColumnInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(q *QbDB) *QbDB
+	}{
+		{name: "injected identifier", build: func(q *QbDB) *QbDB { return q.WhereColumn("a; DROP TABLE t", "=", "b") }},
+		{name: "quoted identifier", build: func(q *QbDB) *QbDB { return q.WhereColumn("a", "=", `"b"`) }},
+		{name: "unknown operator", build: func(q *QbDB) *QbDB { return q.OrWhereColumn("a", "LIKE", "b") }},
+		{name: "invalid pair in group", build: func(q *QbDB) *QbDB { return q.OrWhereColumns([3]string{"a", "=", "1b"}) }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.build(newTestDB().Table("t"))
+			if q.Builder.err == nil {
+				t.Fatal("expected builder error")
+			}
+			if len(q.Builder.whereBindings) != 0 {
+				t.Errorf("invalid condition was appended: %v", q.Builder.whereBindings)
+			}
+		})
+	}
+}
+
+func TestWhereRawWithBoundConditions(t *testing.T) {
+	runQueryCases(t, []queryCase{
+		{
+			name:  "raw only",
+			build: func(q *QbDB) *QbDB { return q.WhereRaw("a = 1").OrWhereRaw("c = 3") },
+			sql:   "SELECT * FROM t WHERE a = 1 OR c = 3",
+		},
+		{
+			name:  "raw followed by bound",
+			build: func(q *QbDB) *QbDB { return q.WhereRaw("a = 1").Where("b", "=", 2) },
+			sql:   "SELECT * FROM t WHERE 1=1  AND b = $1 AND (a = 1)",
+			args:  []any{"2"},
+		},
+		{
+			name:  "raw with OR grouped",
+			build: func(q *QbDB) *QbDB { return q.Where("b", "=", 2).WhereRaw("a = 1").OrWhereRaw("c = 3") },
+			sql:   "SELECT * FROM t WHERE 1=1  AND b = $1 AND (a = 1 OR c = 3)",
+			args:  []any{"2"},
+		},
+		{
+			name:  "AND raw without WhereRaw",
+			build: func(q *QbDB) *QbDB { return q.Where("b", "=", 2).AndWhereRaw("a = 1") },
+			sql:   "SELECT * FROM t WHERE 1=1  AND b = $1 AND a = 1",
+			args:  []any{"2"},
+		},
+	})
+}